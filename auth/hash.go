@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"crypto/sha1"
+	"math/big"
+)
+
+// ServerHash computes the digest sent to the session server, which is the
+// SHA-1 of the inputs printed as a signed (two's complement) hex number.
+func ServerHash(serverID string, sharedSecret, publicKey []byte) string {
+	h := sha1.New()
+	h.Write([]byte(serverID))
+	h.Write(sharedSecret)
+	h.Write(publicKey)
+	digest := h.Sum(nil)
+
+	n := new(big.Int).SetBytes(digest)
+	if digest[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(digest)*8)))
+	}
+	return n.Text(16)
+}
@@ -0,0 +1,26 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/nonya123456/cobble/auth"
+)
+
+func TestServerHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		serverID string
+		want     string
+	}{
+		{name: "Positive digest", serverID: "Notch", want: "4ed1f46bbe04bc756bcb17c0c7ce3e4632f06a48"},
+		{name: "Negative digest", serverID: "jeb_", want: "-7c9d5b0044c130109a5d7b5fb5c317c02b4e28c1"},
+		{name: "Leading zero", serverID: "simon", want: "88e16a1019277b15d58faf0541e11910eb756f6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auth.ServerHash(tt.serverID, nil, nil); got != tt.want {
+				t.Errorf("ServerHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+)
+
+const keyBits = 1024
+
+type KeyPair struct {
+	PrivateKey *rsa.PrivateKey
+	PublicKey  []byte
+}
+
+func GenerateKeyPair() (*KeyPair, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{PrivateKey: privateKey, PublicKey: publicKey}, nil
+}
+
+func (k *KeyPair) Decrypt(ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptPKCS1v15(nil, k.PrivateKey, ciphertext)
+}
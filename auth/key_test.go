@@ -0,0 +1,35 @@
+package auth_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+
+	"github.com/nonya123456/cobble/auth"
+)
+
+func TestKeyPair_Decrypt(t *testing.T) {
+	k, err := auth.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair() error = %v", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(k.PublicKey)
+	if err != nil {
+		t.Fatalf("ParsePKIXPublicKey() error = %v", err)
+	}
+
+	secret := []byte("0123456789abcdef")
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, pub.(*rsa.PublicKey), secret)
+	if err != nil {
+		t.Fatalf("EncryptPKCS1v15() error = %v", err)
+	}
+	got, err := k.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("KeyPair.Decrypt() error = %v", err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("KeyPair.Decrypt() = %v, want %v", got, secret)
+	}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const DefaultSessionServer = "https://sessionserver.mojang.com"
+
+var (
+	ErrNotAuthenticated = errors.New("player has not joined through the session server")
+	ErrInvalidProfileID = errors.New("invalid profile id")
+)
+
+type Property struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type GameProfile struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Properties []Property `json:"properties"`
+}
+
+func (p *GameProfile) UUID() (types.UUID, error) {
+	var u types.UUID
+	b, err := hex.DecodeString(p.ID)
+	if err != nil || len(b) != len(u) {
+		return u, ErrInvalidProfileID
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+// HasJoined asks the session server at baseURL whether username has
+// announced a join for serverHash. An empty ip skips the prevent-proxy check.
+func HasJoined(ctx context.Context, client *http.Client, baseURL, username, serverHash, ip string) (*GameProfile, error) {
+	query := url.Values{}
+	query.Set("username", username)
+	query.Set("serverId", serverHash)
+	if ip != "" {
+		query.Set("ip", ip)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/session/minecraft/hasJoined?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil, ErrNotAuthenticated
+	default:
+		return nil, fmt.Errorf("unexpected session server status: %s", res.Status)
+	}
+
+	var profile GameProfile
+	if err := json.NewDecoder(res.Body).Decode(&profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
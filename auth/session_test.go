@@ -0,0 +1,97 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/auth"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestHasJoined(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/session/minecraft/hasJoined" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("username") != "Notch" || r.URL.Query().Get("serverId") != "abc" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"id":"069a79f444e94726a5befca90e38aaf5","name":"Notch","properties":[{"name":"textures","value":"e30=","signature":"c2ln"}]}`))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name       string
+		username   string
+		serverHash string
+		want       *auth.GameProfile
+		wantErr    error
+	}{
+		{
+			name:       "Joined",
+			username:   "Notch",
+			serverHash: "abc",
+			want: &auth.GameProfile{
+				ID:         "069a79f444e94726a5befca90e38aaf5",
+				Name:       "Notch",
+				Properties: []auth.Property{{Name: "textures", Value: "e30=", Signature: "c2ln"}},
+			},
+		},
+		{
+			name:       "Not joined",
+			username:   "Notch",
+			serverHash: "def",
+			wantErr:    auth.ErrNotAuthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := auth.HasJoined(context.Background(), ts.Client(), ts.URL, tt.username, tt.serverHash, "")
+			if err != tt.wantErr {
+				t.Errorf("HasJoined() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HasJoined() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameProfile_UUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    types.UUID
+		wantErr bool
+	}{
+		{
+			name: "Valid id",
+			id:   "069a79f444e94726a5befca90e38aaf5",
+			want: types.UUID{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5},
+		},
+		{
+			name:    "Short id",
+			id:      "069a79f4",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &auth.GameProfile{ID: tt.id}
+			got, err := p.UUID()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GameProfile.UUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GameProfile.UUID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// protoPackages are the qualifiers resolved to proto packages of the module
+// without an explicit entry in the schema's imports.
+var protoPackages = []string{"codec", "nbt", "text", "types"}
+
+var qualifierPattern = regexp.MustCompile(`(?:^|[^\w.])([a-z_]\w*)\.[A-Z]`)
+
+type generator struct {
+	*schema
+	Module     string
+	ImportPath string
+	Source     string
+}
+
+// generate returns the formatted packet source and round-trip test for s.
+func generate(s *schema, module, importPath, source string) (src, test []byte, err error) {
+	g := &generator{schema: s, Module: module, ImportPath: importPath, Source: source}
+
+	var exprs []string
+	for _, d := range g.structs() {
+		for _, f := range d.Fields {
+			exprs = append(exprs, f.Type)
+		}
+	}
+	srcImports, err := g.imports(exprs, "io", module+"/proto/codec")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range s.Packets {
+		for _, f := range p.Fields {
+			exprs = append(exprs, g.example(f))
+		}
+	}
+	testImports, err := g.imports(exprs, "bytes", "io", "reflect", "testing", importPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if src, err = g.execute(sourceTemplate, srcImports); err != nil {
+		return nil, nil, err
+	}
+	if test, err = g.execute(testTemplate, testImports); err != nil {
+		return nil, nil, err
+	}
+	return src, test, nil
+}
+
+func (g *generator) structs() []structDef {
+	defs := slices.Clone(g.Types)
+	for _, p := range g.Packets {
+		defs = append(defs, p.structDef)
+	}
+	return defs
+}
+
+// imports resolves the package qualifiers used in exprs and returns them
+// with base, grouped as gofmt would.
+func (g *generator) imports(exprs []string, base ...string) ([]string, error) {
+	paths := slices.Clone(base)
+	for _, expr := range exprs {
+		for _, m := range qualifierPattern.FindAllStringSubmatch(expr, -1) {
+			q := m[1]
+			switch {
+			case q == g.Package:
+				paths = append(paths, g.ImportPath)
+			case g.Imports[q] != "":
+				paths = append(paths, g.Imports[q])
+			case slices.Contains(protoPackages, q):
+				paths = append(paths, g.Module+"/proto/"+q)
+			default:
+				return nil, fmt.Errorf("unknown package %q in %q", q, expr)
+			}
+		}
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	var std, other []string
+	for _, p := range paths {
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			other = append(other, strconv.Quote(p))
+		} else {
+			std = append(std, strconv.Quote(p))
+		}
+	}
+	if len(std) > 0 && len(other) > 0 {
+		std = append(std, "")
+	}
+	return append(std, other...), nil
+}
+
+// example returns the value used for f in the round-trip test, or "" to
+// leave the field at its zero value.
+func (g *generator) example(f fieldDef) string {
+	if f.Example != "" || f.Tag == "-" {
+		return f.Example
+	}
+	opts := strings.Split(f.Tag, ",")
+	for _, o := range opts[1:] {
+		if n, ok := strings.CutPrefix(o, "max="); ok {
+			if limit, err := strconv.Atoi(n); err == nil && limit < 4 {
+				return ""
+			}
+		}
+	}
+	switch f.Type {
+	case "bool":
+		return "true"
+	case "int8", "uint8", "int16", "uint16", "int32", "int64", "float32", "float64":
+		return "1"
+	case "string":
+		if opts[0] == "identifier" {
+			return `"minecraft:test"`
+		}
+		if opts[0] == "json" || opts[0] == "nbt" {
+			return ""
+		}
+		return `"test"`
+	case "[]byte":
+		return "[]byte{1, 2, 3}"
+	}
+	return ""
+}
+
+type example struct {
+	Name  string
+	Value string
+}
+
+// Examples returns the non-zero field values of p's round-trip test.
+func (g *generator) Examples(p packetDef) []example {
+	var examples []example
+	for _, f := range p.Fields {
+		if v := g.example(f); v != "" {
+			examples = append(examples, example{f.Name, v})
+		}
+	}
+	return examples
+}
+
+func (g *generator) execute(tmpl *template.Template, imports []string) ([]byte, error) {
+	var buf bytes.Buffer
+	data := struct {
+		*generator
+		ImportList []string
+	}{g, imports}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// receiver names the receiver of a packet's methods after its first
+// letter, or its initials when that would shadow the r or w parameters.
+func receiver(name string) string {
+	rcv := strings.ToLower(name[:1])
+	if rcv != "r" && rcv != "w" {
+		return rcv
+	}
+	var initials strings.Builder
+	for _, c := range name {
+		if unicode.IsUpper(c) {
+			initials.WriteRune(unicode.ToLower(c))
+		}
+	}
+	if initials.Len() > 1 {
+		return initials.String()
+	}
+	return rcv + "p"
+}
+
+var funcs = template.FuncMap{
+	"receiver": receiver,
+	"hex": func(id *int32) string {
+		return fmt.Sprintf("0x%02X", *id)
+	},
+	"tag": func(tag string) string {
+		if tag == "" {
+			return ""
+		}
+		return "`mc:" + strconv.Quote(tag) + "`"
+	},
+}
+
+var sourceTemplate = template.Must(template.New("source").Funcs(funcs).Parse(`// Code generated by packetgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .ImportList}}
+	{{.}}
+{{- end}}
+)
+
+const (
+{{- range .Packets}}
+	{{.Name}}ID = {{hex .ID}}
+{{- end}}
+)
+{{range .Types}}
+type {{.Name}} struct {{- if .Fields}} {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{tag .Tag}}
+{{- end}}
+}{{else}}{}{{end}}
+{{end}}
+{{- range .Packets}}
+// {{.Name}} is the {{.Direction}} {{$.State}} packet {{hex .ID}}.
+type {{.Name}} struct {{- if .Fields}} {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{tag .Tag}}
+{{- end}}
+}{{else}}{}{{end}}
+
+func ({{receiver .Name}} *{{.Name}}) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, {{receiver .Name}})
+}
+
+func ({{receiver .Name}} *{{.Name}}) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, {{receiver .Name}})
+}
+{{end}}`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// Code generated by packetgen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}_test
+
+import (
+{{- range .ImportList}}
+	{{.}}
+{{- end}}
+)
+
+func TestPackets_roundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   io.WriterTo
+		out  io.ReaderFrom
+	}{
+{{- range .Packets}}
+		{
+			name: "{{.Name}}",
+			in: &{{$.Package}}.{{.Name}}{
+{{- with $.Examples .}}{{range .}}
+				{{.Name}}: {{.Value}},
+{{- end}}
+			{{end}}},
+			out: new({{$.Package}}.{{.Name}}),
+		},
+{{- end}}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			wantN, err := tt.in.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			n, err := tt.out.ReadFrom(&buf)
+			if err != nil {
+				t.Fatalf("ReadFrom() error = %v", err)
+			}
+			if n != wantN {
+				t.Errorf("ReadFrom() n = %v, wantN %v", n, wantN)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("ReadFrom() left %d bytes unread", buf.Len())
+			}
+			if !reflect.DeepEqual(tt.out, tt.in) {
+				t.Errorf("ReadFrom() = %+v, want %+v", tt.out, tt.in)
+			}
+		})
+	}
+}
+`))
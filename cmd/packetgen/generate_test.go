@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate_upToDate(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+	}{
+		{
+			name: "Status packets",
+			dir:  "../../proto/status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(tt.dir, "packets.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := parseSchema(data)
+			if err != nil {
+				t.Fatalf("parseSchema() error = %v", err)
+			}
+			dir, err := filepath.Abs(tt.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			module, importPath, err := packageImportPath(dir)
+			if err != nil {
+				t.Fatalf("packageImportPath() error = %v", err)
+			}
+			src, test, err := generate(s, module, importPath, "packets.json")
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+
+			for file, got := range map[string][]byte{"packets_gen.go": src, "packets_gen_test.go": test} {
+				want, err := os.ReadFile(filepath.Join(tt.dir, file))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%s is out of date; run go generate", file)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerate_imports(t *testing.T) {
+	s, err := parseSchema([]byte(`{
+		"package": "login",
+		"state": "login",
+		"types": [{"name": "Property", "fields": [{"name": "Name", "type": "string"}]}],
+		"packets": [{
+			"name": "LoginSuccess",
+			"direction": "clientbound",
+			"id": 2,
+			"fields": [
+				{"name": "UUID", "type": "types.UUID"},
+				{"name": "Properties", "type": "[]Property", "tag": "array", "example": "[]login.Property{{Name: \"textures\"}}"}
+			]
+		}]
+	}`))
+	if err != nil {
+		t.Fatalf("parseSchema() error = %v", err)
+	}
+	src, test, err := generate(s, "example.com/m", "example.com/m/login", "packets.json")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"Source imports types", src, `"example.com/m/proto/types"`},
+		{"Source declares helper", src, "type Property struct {\n\tName string\n}"},
+		{"Source tags field", src, "Properties []Property `mc:\"array\"`"},
+		{"Source declares ID", src, "LoginSuccessID = 0x02"},
+		{"Test imports package", test, `"example.com/m/login"`},
+		{"Test uses example", test, `Properties: []login.Property{{Name: "textures"}},`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !bytes.Contains(tt.got, []byte(tt.want)) {
+				t.Errorf("generate() output does not contain %q:\n%s", tt.want, tt.got)
+			}
+		})
+	}
+}
+
+func TestReceiver(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "PingRequest", want: "p"},
+		{name: "RegistryData", want: "rd"},
+		{name: "WorldEvent", want: "we"},
+		{name: "Respawn", want: "rp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := receiver(tt.name); got != tt.want {
+				t.Errorf("receiver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Command packetgen generates packet types from a JSON protocol schema.
+//
+// It is meant to be run with go generate from the package that owns the
+// schema:
+//
+//	//go:generate go run github.com/nonya123456/cobble/cmd/packetgen -in packets.json
+//
+// The schema names the package and connection state, and lists the
+// packets with their direction, ID and fields:
+//
+//	{
+//	  "package": "status",
+//	  "state": "status",
+//	  "packets": [
+//	    {
+//	      "name": "PingRequest",
+//	      "direction": "serverbound",
+//	      "id": 1,
+//	      "fields": [{"name": "Payload", "type": "int64"}]
+//	    }
+//	  ]
+//	}
+//
+// A field's "tag" becomes its `mc` struct tag and "example" is the Go
+// expression used for it in the generated round-trip test. Helper structs
+// without an ID can be listed under "types".
+//
+// packetgen writes the packets, their ID constants and ReadFrom/WriteTo
+// methods to packets_gen.go, and round-trip tests to packets_gen_test.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("packetgen: ")
+
+	in := flag.String("in", "packets.json", "protocol schema to read")
+	out := flag.String("out", "packets_gen.go", "Go file to write")
+	flag.Parse()
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := parseSchema(data)
+	if err != nil {
+		log.Fatalf("%s: %v", *in, err)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(*out))
+	if err != nil {
+		log.Fatal(err)
+	}
+	module, importPath, err := packageImportPath(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, test, err := generate(s, module, importPath, filepath.Base(*in))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(strings.TrimSuffix(*out, ".go")+"_test.go", test, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// packageImportPath returns the module containing dir, found through the
+// nearest go.mod, and the import path of dir within it.
+func packageImportPath(dir string) (module, importPath string, err error) {
+	for root := dir; ; {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			module := modulePath(data)
+			if module == "" {
+				return "", "", fmt.Errorf("no module directive in %s", filepath.Join(root, "go.mod"))
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", "", err
+			}
+			if rel == "." {
+				return module, module, nil
+			}
+			return module, module + "/" + filepath.ToSlash(rel), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", "", err
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", "", fmt.Errorf("no go.mod found above %s", dir)
+		}
+		root = parent
+	}
+}
+
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module"); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
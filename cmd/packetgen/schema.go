@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+)
+
+type schema struct {
+	Package string            `json:"package"`
+	State   string            `json:"state"`
+	Imports map[string]string `json:"imports"`
+	Types   []structDef       `json:"types"`
+	Packets []packetDef       `json:"packets"`
+}
+
+type structDef struct {
+	Name   string     `json:"name"`
+	Fields []fieldDef `json:"fields"`
+}
+
+type packetDef struct {
+	structDef
+	Direction string `json:"direction"`
+	ID        *int32 `json:"id"`
+}
+
+type fieldDef struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Tag     string `json:"tag"`
+	Example string `json:"example"`
+}
+
+var states = map[string]bool{
+	"handshaking":   true,
+	"status":        true,
+	"login":         true,
+	"configuration": true,
+	"play":          true,
+}
+
+func parseSchema(data []byte) (*schema, error) {
+	var s schema
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&s); err != nil {
+		return nil, err
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *schema) validate() error {
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("invalid package %q", s.Package)
+	}
+	if !states[s.State] {
+		return fmt.Errorf("unknown state %q", s.State)
+	}
+	if len(s.Packets) == 0 {
+		return errors.New("no packets")
+	}
+
+	names := make(map[string]bool)
+	for _, t := range s.Types {
+		if err := t.validate(names); err != nil {
+			return err
+		}
+	}
+	ids := make(map[string]string)
+	for _, p := range s.Packets {
+		if err := p.validate(names); err != nil {
+			return err
+		}
+		if p.Direction != "serverbound" && p.Direction != "clientbound" {
+			return fmt.Errorf("%s: unknown direction %q", p.Name, p.Direction)
+		}
+		if p.ID == nil || *p.ID < 0 {
+			return fmt.Errorf("%s: missing or negative id", p.Name)
+		}
+		key := fmt.Sprintf("%s/%#02x", p.Direction, *p.ID)
+		if other, ok := ids[key]; ok {
+			return fmt.Errorf("%s: %s id %#02x already used by %s", p.Name, p.Direction, *p.ID, other)
+		}
+		ids[key] = p.Name
+	}
+	return nil
+}
+
+func (d *structDef) validate(names map[string]bool) error {
+	if !token.IsExported(d.Name) || !token.IsIdentifier(d.Name) {
+		return fmt.Errorf("invalid type name %q", d.Name)
+	}
+	if names[d.Name] {
+		return fmt.Errorf("duplicate type %s", d.Name)
+	}
+	names[d.Name] = true
+
+	fields := make(map[string]bool)
+	for _, f := range d.Fields {
+		if !token.IsExported(f.Name) || !token.IsIdentifier(f.Name) {
+			return fmt.Errorf("%s: invalid field name %q", d.Name, f.Name)
+		}
+		if fields[f.Name] {
+			return fmt.Errorf("%s: duplicate field %s", d.Name, f.Name)
+		}
+		fields[f.Name] = true
+		if f.Type == "" {
+			return fmt.Errorf("%s.%s: missing type", d.Name, f.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import "testing"
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "Valid",
+			data: `{"package": "status", "state": "status", "packets": [{"name": "PingRequest", "direction": "serverbound", "id": 1, "fields": [{"name": "Payload", "type": "int64"}]}]}`,
+		},
+		{
+			name:    "Unknown state",
+			data:    `{"package": "status", "state": "lobby", "packets": [{"name": "PingRequest", "direction": "serverbound", "id": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown direction",
+			data:    `{"package": "status", "state": "status", "packets": [{"name": "PingRequest", "direction": "upstream", "id": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing id",
+			data:    `{"package": "status", "state": "status", "packets": [{"name": "PingRequest", "direction": "serverbound"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Duplicate id",
+			data:    `{"package": "status", "state": "status", "packets": [{"name": "A", "direction": "serverbound", "id": 1}, {"name": "B", "direction": "serverbound", "id": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name: "Same id in both directions",
+			data: `{"package": "status", "state": "status", "packets": [{"name": "A", "direction": "serverbound", "id": 1}, {"name": "B", "direction": "clientbound", "id": 1}]}`,
+		},
+		{
+			name:    "Unexported name",
+			data:    `{"package": "status", "state": "status", "packets": [{"name": "ping", "direction": "serverbound", "id": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Duplicate field",
+			data:    `{"package": "status", "state": "status", "packets": [{"name": "A", "direction": "serverbound", "id": 1, "fields": [{"name": "X", "type": "int32"}, {"name": "X", "type": "int32"}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing field type",
+			data:    `{"package": "status", "state": "status", "packets": [{"name": "A", "direction": "serverbound", "id": 1, "fields": [{"name": "X"}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown key",
+			data:    `{"package": "status", "state": "status", "version": 768, "packets": [{"name": "A", "direction": "serverbound", "id": 1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "No packets",
+			data:    `{"package": "status", "state": "status"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSchema([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+// syncedRegistries are the data-driven registries the server sends to
+// clients during configuration.
+var syncedRegistries = []string{
+	"banner_pattern",
+	"chat_type",
+	"damage_type",
+	"dimension_type",
+	"enchantment",
+	"instrument",
+	"jukebox_song",
+	"painting_variant",
+	"trim_material",
+	"trim_pattern",
+	"wolf_variant",
+	"worldgen/biome",
+}
+
+const namespace = "minecraft"
+
+// data mirrors the JSON form of vanilla.Data.
+type data struct {
+	KnownPacks       []knownPack                    `json:"knownPacks"`
+	FeatureFlags     []string                       `json:"featureFlags"`
+	Registries       []registry                     `json:"registries"`
+	StaticRegistries []staticRegistry               `json:"staticRegistries"`
+	Tags             map[string]map[string][]string `json:"tags"`
+}
+
+type knownPack struct {
+	Namespace string `json:"namespace"`
+	ID        string `json:"id"`
+	Version   string `json:"version"`
+}
+
+type registry struct {
+	ID      string  `json:"id"`
+	Entries []entry `json:"entries"`
+}
+
+type entry struct {
+	ID   string          `json:"id"`
+	Data json.RawMessage `json:"data"`
+}
+
+type staticRegistry struct {
+	ID      string   `json:"id"`
+	Entries []string `json:"entries"`
+}
+
+// report is the registries report, which lists the static registries
+// with the network ID of each entry.
+type report map[string]struct {
+	Entries map[string]struct {
+		ProtocolID int `json:"protocol_id"`
+	} `json:"entries"`
+}
+
+type tagFile struct {
+	Values []tagValue `json:"values"`
+}
+
+// tagValue is an entry or #tag reference, which may be marked optional.
+type tagValue struct {
+	ID       string
+	Required bool
+}
+
+func (v *tagValue) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &v.ID); err == nil {
+		v.Required = true
+		return nil
+	}
+	var o struct {
+		ID       string `json:"id"`
+		Required *bool  `json:"required"`
+	}
+	if err := json.Unmarshal(b, &o); err != nil {
+		return err
+	}
+	v.ID = o.ID
+	v.Required = o.Required == nil || *o.Required
+	return nil
+}
+
+// generate reads the data generator output in fsys.
+func generate(fsys fs.FS, packVersions []string, registries []string) (*data, error) {
+	d := &data{FeatureFlags: []string{namespace + ":vanilla"}}
+	for _, v := range packVersions {
+		d.KnownPacks = append(d.KnownPacks, knownPack{Namespace: namespace, ID: "core", Version: v})
+	}
+
+	entries := make(map[string][]string)
+	for _, name := range registries {
+		r, err := readRegistry(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		d.Registries = append(d.Registries, r)
+		for _, e := range r.Entries {
+			entries[r.ID] = append(entries[r.ID], e.ID)
+		}
+	}
+
+	b, err := fs.ReadFile(fsys, "reports/registries.json")
+	if err != nil {
+		return nil, err
+	}
+	var rep report
+	if err := json.Unmarshal(b, &rep); err != nil {
+		return nil, fmt.Errorf("reports/registries.json: %w", err)
+	}
+	static := make(map[string][]string)
+	for id, r := range rep {
+		ids := make([]string, len(r.Entries))
+		for entry, e := range r.Entries {
+			if e.ProtocolID < 0 || e.ProtocolID >= len(ids) || ids[e.ProtocolID] != "" {
+				return nil, fmt.Errorf("reports/registries.json: %s has an invalid protocol ID for %s", id, entry)
+			}
+			ids[e.ProtocolID] = entry
+		}
+		static[id] = ids
+	}
+
+	tags, err := readTags(fsys, entries, static)
+	if err != nil {
+		return nil, err
+	}
+	d.Tags = tags
+	for _, id := range slices.Sorted(maps.Keys(tags)) {
+		if _, ok := entries[id]; !ok {
+			d.StaticRegistries = append(d.StaticRegistries, staticRegistry{ID: id, Entries: static[id]})
+		}
+	}
+	return d, nil
+}
+
+// readRegistry reads the entries of a data-driven registry in the order
+// vanilla loads them, sorted by ID.
+func readRegistry(fsys fs.FS, name string) (registry, error) {
+	r := registry{ID: namespace + ":" + name}
+	dir := path.Join("data", namespace, name)
+	files, err := jsonFiles(fsys, dir)
+	if err != nil {
+		return registry{}, err
+	}
+	if len(files) == 0 {
+		return registry{}, fmt.Errorf("registry %s has no entries in %s", r.ID, dir)
+	}
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return registry{}, err
+		}
+		if !json.Valid(b) {
+			return registry{}, fmt.Errorf("%s: invalid JSON", path.Join(dir, file))
+		}
+		r.Entries = append(r.Entries, entry{ID: namespace + ":" + strings.TrimSuffix(file, ".json"), Data: b})
+	}
+	slices.SortFunc(r.Entries, func(a, b entry) int { return strings.Compare(a.ID, b.ID) })
+	return r, nil
+}
+
+// readTags resolves the tags of every registry sent to clients. Tags of
+// other registries, such as structures, are skipped.
+func readTags(fsys fs.FS, synced, static map[string][]string) (map[string]map[string][]string, error) {
+	dir := path.Join("data", namespace, "tags")
+	files, err := jsonFiles(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tag paths nest below their registry's path, so the registry is the
+	// longest known prefix.
+	raw := make(map[string]map[string]tagFile)
+	for _, file := range files {
+		name := strings.TrimSuffix(file, ".json")
+		var registry string
+		for prefix := path.Dir(name); prefix != "."; prefix = path.Dir(prefix) {
+			id := namespace + ":" + prefix
+			if _, ok := synced[id]; ok {
+				registry = id
+			} else if _, ok := static[id]; ok {
+				registry = id
+			}
+			if registry != "" {
+				name = strings.TrimPrefix(name, prefix+"/")
+				break
+			}
+		}
+		if registry == "" {
+			continue
+		}
+		b, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		var t tagFile
+		if err := json.Unmarshal(b, &t); err != nil {
+			return nil, fmt.Errorf("%s: %w", path.Join(dir, file), err)
+		}
+		if raw[registry] == nil {
+			raw[registry] = make(map[string]tagFile)
+		}
+		raw[registry][namespace+":"+name] = t
+	}
+
+	tags := make(map[string]map[string][]string)
+	for registry, files := range raw {
+		entries := synced[registry]
+		if entries == nil {
+			entries = static[registry]
+		}
+		r := &resolver{files: files, entries: entries, resolved: make(map[string][]string)}
+		tags[registry] = make(map[string][]string)
+		for name := range files {
+			ids, err := r.resolve(name, nil)
+			if err != nil {
+				return nil, fmt.Errorf("%s tag %s: %w", registry, name, err)
+			}
+			tags[registry][name] = ids
+		}
+	}
+	return tags, nil
+}
+
+type resolver struct {
+	files    map[string]tagFile
+	entries  []string
+	resolved map[string][]string
+}
+
+// resolve returns the entries of a tag in the order they are listed,
+// without duplicates. stack holds the tags being resolved, to detect
+// cycles.
+func (r *resolver) resolve(name string, stack []string) ([]string, error) {
+	if ids, ok := r.resolved[name]; ok {
+		return ids, nil
+	}
+	if slices.Contains(stack, name) {
+		return nil, fmt.Errorf("tag %s references itself", name)
+	}
+	t, ok := r.files[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tag %s", name)
+	}
+	stack = append(stack, name)
+
+	ids := []string{}
+	for _, v := range t.Values {
+		if ref, ok := strings.CutPrefix(v.ID, "#"); ok {
+			if _, exists := r.files[ref]; !exists && !v.Required {
+				continue
+			}
+			nested, err := r.resolve(ref, stack)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range nested {
+				if !slices.Contains(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+			continue
+		}
+		if !slices.Contains(r.entries, v.ID) {
+			if v.Required {
+				return nil, fmt.Errorf("unknown entry %s", v.ID)
+			}
+			continue
+		}
+		if !slices.Contains(ids, v.ID) {
+			ids = append(ids, v.ID)
+		}
+	}
+	r.resolved[name] = ids
+	return ids, nil
+}
+
+// jsonFiles returns the JSON files below dir, relative to it and sorted.
+func jsonFiles(fsys fs.FS, dir string) ([]string, error) {
+	var files []string
+	err := fs.WalkDir(fsys, dir, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !e.IsDir() && path.Ext(p) == ".json" {
+			files = append(files, strings.TrimPrefix(p, dir+"/"))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	return files, nil
+}
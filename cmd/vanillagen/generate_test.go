@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+func TestGenerate(t *testing.T) {
+	d, err := generate(os.DirFS("testdata/generated"), []string{"1.21.2", "1.21.3"}, []string{"dimension_type", "worldgen/biome"})
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+
+	var ids [][]string
+	for _, r := range d.Registries {
+		var entries []string
+		for _, e := range r.Entries {
+			entries = append(entries, e.ID)
+		}
+		ids = append(ids, append([]string{r.ID}, entries...))
+	}
+	wantIDs := [][]string{
+		{"minecraft:dimension_type", "minecraft:overworld", "minecraft:the_end"},
+		{"minecraft:worldgen/biome", "minecraft:plains", "minecraft:the_void"},
+	}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("generate() registries = %v, want %v", ids, wantIDs)
+	}
+
+	wantTags := map[string]map[string][]string{
+		"minecraft:block": {"minecraft:mineable/axe": {"minecraft:oak_log"}},
+		"minecraft:fluid": {
+			"minecraft:liquid": {"minecraft:water", "minecraft:flowing_water", "minecraft:lava"},
+			"minecraft:water":  {"minecraft:water", "minecraft:flowing_water"},
+		},
+		"minecraft:worldgen/biome": {"minecraft:is_overworld": {"minecraft:plains"}},
+	}
+	if !reflect.DeepEqual(d.Tags, wantTags) {
+		t.Errorf("generate() tags = %v, want %v", d.Tags, wantTags)
+	}
+
+	wantStatic := []staticRegistry{
+		{ID: "minecraft:block", Entries: []string{"minecraft:air", "minecraft:stone", "minecraft:oak_log"}},
+		{ID: "minecraft:fluid", Entries: []string{"minecraft:empty", "minecraft:flowing_water", "minecraft:water", "minecraft:flowing_lava", "minecraft:lava"}},
+	}
+	if !reflect.DeepEqual(d.StaticRegistries, wantStatic) {
+		t.Errorf("generate() static registries = %v, want %v", d.StaticRegistries, wantStatic)
+	}
+}
+
+// TestGenerate_load checks that the output is what package vanilla reads.
+func TestGenerate_load(t *testing.T) {
+	d, err := generate(os.DirFS("testdata/generated"), []string{"1.21.3"}, []string{"dimension_type", "worldgen/biome"})
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got vanilla.Data
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	e, _ := got.Registry("minecraft:dimension_type").Lookup("minecraft:overworld")
+	if want := map[string]any{"height": int32(384), "has_skylight": int8(1), "coordinate_scale": 1.0}; !reflect.DeepEqual(e.Data, want) {
+		t.Errorf("overworld data = %v, want %v", e.Data, want)
+	}
+	if _, err := got.RegistryData(false); err != nil {
+		t.Errorf("Data.RegistryData() error = %v", err)
+	}
+	if _, err := got.UpdateTags(); err != nil {
+		t.Errorf("Data.UpdateTags() error = %v", err)
+	}
+}
+
+func TestGenerate_invalid(t *testing.T) {
+	registries := `{"minecraft:fluid": {"entries": {"minecraft:empty": {"protocol_id": 0}}}}`
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "Missing registry",
+			files: map[string]string{"reports/registries.json": registries},
+		},
+		{
+			name: "Unknown entry",
+			files: map[string]string{
+				"reports/registries.json":                      registries,
+				"data/minecraft/dimension_type/overworld.json": `{}`,
+				"data/minecraft/tags/fluid/water.json":         `{"values": ["minecraft:water"]}`,
+			},
+		},
+		{
+			name: "Cyclic tags",
+			files: map[string]string{
+				"reports/registries.json":                      registries,
+				"data/minecraft/dimension_type/overworld.json": `{}`,
+				"data/minecraft/tags/fluid/a.json":             `{"values": ["#minecraft:b"]}`,
+				"data/minecraft/tags/fluid/b.json":             `{"values": ["#minecraft:a"]}`,
+			},
+		},
+		{
+			name: "Invalid protocol ID",
+			files: map[string]string{
+				"reports/registries.json":                      `{"minecraft:fluid": {"entries": {"minecraft:empty": {"protocol_id": 1}}}}`,
+				"data/minecraft/dimension_type/overworld.json": `{}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, data := range tt.files {
+				fsys[name] = &fstest.MapFile{Data: []byte(data)}
+			}
+			if _, err := generate(fsys, []string{"1.21.3"}, []string{"dimension_type"}); err == nil {
+				t.Errorf("generate() error = nil, want an error")
+			}
+		})
+	}
+}
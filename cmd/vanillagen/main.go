@@ -0,0 +1,48 @@
+// Command vanillagen generates the bundled registry data of package
+// vanilla from the output of the vanilla data generator.
+//
+// The data generator ships in the server jar of each release. Run it with
+// the server and reports options, then point vanillagen at its output:
+//
+//	java -DbundlerMainClass=net.minecraft.data.Main -jar server.jar --server --reports
+//	go run github.com/nonya123456/cobble/cmd/vanillagen -in generated -pack 1.21.2,1.21.3 -out vanilla/data/768.json
+//
+// The contents of every entry of the synchronized registries are copied
+// as they appear in the vanilla data pack, and every tag of a registry
+// sent to clients is resolved to its entries. Static registries with tags
+// are listed from the registries report, ordered by network ID.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("vanillagen: ")
+
+	in := flag.String("in", "generated", "output directory of the vanilla data generator")
+	out := flag.String("out", "", "JSON file to write")
+	pack := flag.String("pack", "", "comma-separated versions of the minecraft:core pack the data matches")
+	flag.Parse()
+	if *out == "" || *pack == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	d, err := generate(os.DirFS(*in), strings.Split(*pack, ","), syncedRegistries)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, append(b, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
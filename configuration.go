@@ -0,0 +1,107 @@
+package cobble
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const serverBrand = "cobble"
+
+// startConfiguration announces the server and the data packs it uses.
+// The client answers with the packs it has, after which the registries
+// are sent.
+func (c *Conn) startConfiguration() error {
+	data, err := c.Server.registryData(c.Handshake.ProtocolVersion)
+	if err != nil {
+		c.Disconnect(text.Plain("Internal server error"))
+		return err
+	}
+	c.registryData = data
+	c.setKeepAliveState(proto.StateConfiguration)
+	go c.keepAlive()
+
+	var buf bytes.Buffer
+	brand := types.String(serverBrand)
+	if _, err := brand.WriteTo(&buf); err != nil {
+		return err
+	}
+	if err := c.WriteMessage(&configuration.ClientboundPluginMessage{Channel: configuration.BrandChannel, Data: buf.Bytes()}); err != nil {
+		return err
+	}
+	if err := c.WriteMessage(&configuration.FeatureFlags{Flags: data.FeatureFlags}); err != nil {
+		return err
+	}
+	return c.WriteMessage(&configuration.ClientboundKnownPacks{Packs: data.KnownPacks})
+}
+
+func handleClientInformation(c *Conn, p proto.Packet) error {
+	return readPacket(p, &c.ClientInformation)
+}
+
+func handlePluginMessage(c *Conn, p proto.Packet) error {
+	var msg configuration.ServerboundPluginMessage
+	if err := readPacket(p, &msg); err != nil {
+		return err
+	}
+	if msg.Channel == configuration.BrandChannel {
+		var brand types.String
+		if _, err := brand.ReadFrom(bytes.NewReader(msg.Data)); err != nil {
+			return err
+		}
+		c.Brand = string(brand)
+	}
+	return nil
+}
+
+func handleKnownPacks(c *Conn, p proto.Packet) error {
+	var res configuration.ServerboundKnownPacks
+	if err := readPacket(p, &res); err != nil {
+		return err
+	}
+	data := c.registryData
+	if data == nil {
+		return errUnexpectedPacket
+	}
+	hasKnownPack := slices.ContainsFunc(res.Packs, func(pack configuration.KnownPack) bool {
+		return slices.Contains(data.KnownPacks, pack)
+	})
+	registries, err := data.RegistryData(hasKnownPack)
+	if err != nil {
+		// Entries without bundled contents can only be sent to clients
+		// that have the vanilla pack.
+		c.Disconnect(text.Plain("Incompatible client data packs"))
+		return err
+	}
+	tags, err := data.UpdateTags()
+	if err != nil {
+		c.Disconnect(text.Plain("Internal server error"))
+		return err
+	}
+
+	for i := range registries {
+		if err := c.WriteMessage(&registries[i]); err != nil {
+			return err
+		}
+	}
+	if err := c.WriteMessage(&tags); err != nil {
+		return err
+	}
+	// A keep-alive sent after Finish Configuration could reach the client
+	// in either state, so they are paused until play begins.
+	c.setKeepAliveState(proto.StateHandshaking)
+	return c.WriteMessage(&configuration.FinishConfiguration{})
+}
+
+func handleAcknowledgeFinishConfiguration(c *Conn, p proto.Packet) error {
+	var ack configuration.AcknowledgeFinishConfiguration
+	if err := readPacket(p, &ack); err != nil {
+		return err
+	}
+	c.SetState(proto.StatePlay)
+	return c.join()
+}
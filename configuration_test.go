@@ -0,0 +1,128 @@
+package cobble
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+// startConfiguration logs in and reads the packets that open the
+// configuration state, returning the packs the server offered.
+func startConfiguration(t *testing.T, c *proto.Conn) []configuration.KnownPack {
+	t.Helper()
+	startLogin(t, c, "Notch")
+	readTestPacket(t, c, login.LoginSuccessID, &login.LoginSuccess{})
+	writeTestPacket(t, c, login.LoginAcknowledgedID, &login.LoginAcknowledged{})
+
+	var brand configuration.ClientboundPluginMessage
+	readTestPacket(t, c, configuration.ClientboundPluginMessageID, &brand)
+	if want := []byte("\x06cobble"); brand.Channel != configuration.BrandChannel || !reflect.DeepEqual(brand.Data, want) {
+		t.Errorf("ClientboundPluginMessage = %v, want brand %q", brand, want)
+	}
+	readTestPacket(t, c, configuration.FeatureFlagsID, &configuration.FeatureFlags{})
+	var packs configuration.ClientboundKnownPacks
+	readTestPacket(t, c, configuration.ClientboundKnownPacksID, &packs)
+	return packs.Packs
+}
+
+func TestServer_configuration(t *testing.T) {
+	played := make(chan *Conn, 1)
+	s := &Server{}
+	s.HandleFunc(proto.StatePlay, 0x7F, func(c *Conn, p proto.Packet) error {
+		played <- c
+		return nil
+	})
+	c := dialTest(t, s)
+	packs := startConfiguration(t, c)
+
+	info := configuration.ClientInformation{Locale: "en_us", ViewDistance: 12, MainHand: configuration.MainHandRight}
+	writeTestPacket(t, c, configuration.ClientInformationID, &info)
+	writeTestPacket(t, c, configuration.ServerboundPluginMessageID, &configuration.ServerboundPluginMessage{Channel: configuration.BrandChannel, Data: []byte("\x07vanilla")})
+	writeTestPacket(t, c, configuration.ServerboundKnownPacksID, &configuration.ServerboundKnownPacks{Packs: packs[len(packs)-1:]})
+
+	data, err := vanilla.Load(768)
+	if err != nil {
+		t.Fatalf("vanilla.Load() error = %v", err)
+	}
+	for _, r := range data.Registries {
+		var got configuration.RegistryData
+		readTestPacket(t, c, configuration.RegistryDataID, &got)
+		if got.Registry != r.ID || len(got.Entries) != len(r.Entries) {
+			t.Fatalf("RegistryData = %v with %d entries, want %v with %d", got.Registry, len(got.Entries), r.ID, len(r.Entries))
+		}
+		for i, e := range got.Entries {
+			if e.ID != r.Entries[i].ID || e.Data != nil {
+				t.Errorf("RegistryData %v entry %d = %v, want %v without data", got.Registry, i, e, r.Entries[i].ID)
+			}
+		}
+	}
+	readTestPacket(t, c, configuration.UpdateTagsID, &configuration.UpdateTags{})
+	readTestPacket(t, c, configuration.FinishConfigurationID, &configuration.FinishConfiguration{})
+	writeTestPacket(t, c, configuration.AcknowledgeFinishConfigurationID, &configuration.AcknowledgeFinishConfiguration{})
+	readJoin(t, c)
+	writeTestPacket(t, c, 0x7F, &configuration.AcknowledgeFinishConfiguration{})
+
+	sc := <-played
+	if !reflect.DeepEqual(sc.ClientInformation, info) {
+		t.Errorf("Conn.ClientInformation = %v, want %v", sc.ClientInformation, info)
+	}
+	if sc.Brand != "vanilla" {
+		t.Errorf("Conn.Brand = %v, want vanilla", sc.Brand)
+	}
+}
+
+func TestServer_configurationUnknownPacks(t *testing.T) {
+	c := dialTest(t, &Server{})
+	startConfiguration(t, c)
+	writeTestPacket(t, c, configuration.ServerboundKnownPacksID, &configuration.ServerboundKnownPacks{
+		Packs: []configuration.KnownPack{{Namespace: "minecraft", ID: "core", Version: "1.20.1"}},
+	})
+
+	var res configuration.Disconnect
+	readTestPacket(t, c, configuration.DisconnectID, &res)
+	if want := text.Plain("Incompatible client data packs"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+}
+
+func TestServer_RegistryData(t *testing.T) {
+	custom := map[string]any{"height": int32(256)}
+	s := &Server{RegistryData: func(protocol int32) (*vanilla.Data, error) {
+		d, err := vanilla.Load(protocol)
+		if err != nil {
+			return nil, err
+		}
+		d.Registry("minecraft:dimension_type").Set("example:flat", custom)
+		d.SetTag("minecraft:worldgen/biome", "example:flat", "minecraft:plains")
+		return d, nil
+	}}
+	c := dialTest(t, s)
+	packs := startConfiguration(t, c)
+	writeTestPacket(t, c, configuration.ServerboundKnownPacksID, &configuration.ServerboundKnownPacks{Packs: packs})
+
+	var gotEntry configuration.RegistryEntry
+	for {
+		packet, err := c.ReadPacket()
+		if err != nil {
+			t.Fatalf("Conn.ReadPacket() error = %v", err)
+		}
+		if packet.ID != configuration.RegistryDataID {
+			break
+		}
+		var got configuration.RegistryData
+		if err := readPacket(packet, &got); err != nil {
+			t.Fatalf("RegistryData.ReadFrom() error = %v", err)
+		}
+		if got.Registry == "minecraft:dimension_type" {
+			gotEntry = got.Entries[len(got.Entries)-1]
+		}
+	}
+	if gotEntry.ID != "example:flat" || !reflect.DeepEqual(gotEntry.Data, custom) {
+		t.Errorf("custom dimension type = %v, want example:flat with %v", gotEntry, custom)
+	}
+}
@@ -0,0 +1,124 @@
+package cobble
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+// Conn is a client connection as seen by packet handlers. Fields are
+// filled in as the connection progresses through the protocol states.
+type Conn struct {
+	*proto.Conn
+	Server *Server
+
+	Handshake  handshaking.Handshake
+	Username   string
+	UUID       types.UUID
+	Properties []login.Property
+
+	// ClientInformation and Brand are reported by the client during
+	// configuration.
+	ClientInformation configuration.ClientInformation
+	Brand             string
+
+	loginName    string
+	loggedIn     bool
+	verifyToken  []byte
+	registryData *vanilla.Data
+	done         chan struct{}
+
+	keepAliveMu      sync.Mutex
+	keepAliveState   proto.State
+	keepAlivePending bool
+	keepAliveID      int64
+	keepAliveSent    time.Time
+	lastKeepAlive    time.Time
+	latency          time.Duration
+}
+
+func newConn(s *Server, conn net.Conn) *Conn {
+	c := &Conn{
+		Conn:   proto.NewConn(conn),
+		Server: s,
+		done:   make(chan struct{}),
+	}
+	if s.MaxPacketLength > 0 {
+		c.SetMaxPacketLength(s.MaxPacketLength)
+	}
+	if d := s.writeTimeout(); d > 0 {
+		c.SetWriteTimeout(d)
+	}
+	return c
+}
+
+// setReadDeadline bounds the next read by the idle timeout of the current
+// state and, until login completes or for the whole status exchange, by
+// loginDeadline.
+func (c *Conn) setReadDeadline(loginDeadline time.Time) {
+	var deadline time.Time
+	state := c.State()
+	if d := c.Server.idleTimeout(state); d > 0 {
+		deadline = time.Now().Add(d)
+	}
+	if state < proto.StateConfiguration && !loginDeadline.IsZero() &&
+		(deadline.IsZero() || loginDeadline.Before(deadline)) {
+		deadline = loginDeadline
+	}
+	c.SetReadDeadline(deadline)
+}
+
+// Version returns the protocol version the client announced in its
+// handshake and whether the server supports it.
+func (c *Conn) Version() (proto.Version, bool) {
+	return proto.LookupVersion(c.Handshake.ProtocolVersion)
+}
+
+// protocol returns the protocol version whose packet IDs are used for the
+// connection. Before the handshake and for unsupported clients, which only
+// get as far as the status exchange or a login disconnect, those of the
+// latest version are used.
+func (c *Conn) protocol() int32 {
+	if v, ok := c.Version(); ok {
+		return v.Protocol
+	}
+	return proto.LatestVersion.Protocol
+}
+
+// WriteMessage sends m with the packet ID the server's registry assigns it
+// for the client's protocol version and the current state.
+func (c *Conn) WriteMessage(m proto.Message) error {
+	return c.writeMessage(c.State(), m)
+}
+
+func (c *Conn) writeMessage(state proto.State, m proto.Message) error {
+	id, err := c.Server.registry().ID(c.protocol(), state, proto.Clientbound, m)
+	if err != nil {
+		return err
+	}
+	return c.WritePacket(id, m)
+}
+
+// Disconnect sends reason to the client if its current state has a
+// disconnect packet. The connection is closed by the caller.
+func (c *Conn) Disconnect(reason text.Component) error {
+	switch c.State() {
+	case proto.StateLogin:
+		return c.WriteMessage(&login.Disconnect{Reason: reason})
+	case proto.StateConfiguration:
+		return c.WriteMessage(&configuration.Disconnect{Reason: reason})
+	case proto.StatePlay:
+		return c.WriteMessage(&play.Disconnect{Reason: reason})
+	default:
+		return nil
+	}
+}
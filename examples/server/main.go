@@ -1,10 +1,33 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
 	"github.com/nonya123456/cobble"
 )
 
 func main() {
 	s := cobble.Server{Addr: ":25565"}
-	s.Run()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := s.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Shutdown: %v\n", err)
+		}
+	}()
+
+	if err := s.Run(); err != cobble.ErrServerClosed {
+		log.Fatal(err)
+	}
+	<-closed
 }
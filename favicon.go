@@ -0,0 +1,66 @@
+package cobble
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+)
+
+const (
+	faviconSize   = 64
+	faviconPrefix = "data:image/png;base64,"
+)
+
+var (
+	ErrInvalidFaviconSize = errors.New("favicon must be 64x64 pixels")
+)
+
+// EncodeFavicon encodes img as the data URI used by the status favicon.
+func EncodeFavicon(img image.Image) (string, error) {
+	if err := checkFaviconSize(img.Bounds().Dx(), img.Bounds().Dy()); err != nil {
+		return "", err
+	}
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return faviconPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// LoadFavicon reads a 64x64 image from path. PNG files are sent as they
+// are; any other supported format is re-encoded as PNG.
+func LoadFavicon(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("favicon %s: %w", path, err)
+	}
+	if err := checkFaviconSize(config.Width, config.Height); err != nil {
+		return "", fmt.Errorf("favicon %s: %w", path, err)
+	}
+	if format == "png" {
+		return faviconPrefix + base64.StdEncoding.EncodeToString(data), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("favicon %s: %w", path, err)
+	}
+	return EncodeFavicon(img)
+}
+
+func checkFaviconSize(width, height int) error {
+	if width != faviconSize || height != faviconSize {
+		return fmt.Errorf("%w, got %dx%d", ErrInvalidFaviconSize, width, height)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package cobble
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testImage(size int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for x := range size {
+		img.Set(x, x, color.RGBA{R: 255, A: 255})
+	}
+	return img
+}
+
+func writeTestImage(t *testing.T, name string, img image.Image) string {
+	t.Helper()
+	buf := bytes.Buffer{}
+	var err error
+	if strings.HasSuffix(name, ".png") {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatalf("encode %s: %v", name, err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func decodeFavicon(t *testing.T, favicon string) image.Image {
+	t.Helper()
+	data, ok := strings.CutPrefix(favicon, faviconPrefix)
+	if !ok {
+		t.Fatalf("favicon %q does not have prefix %q", favicon, faviconPrefix)
+	}
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("favicon is not base64: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("favicon is not a PNG: %v", err)
+	}
+	return img
+}
+
+func TestEncodeFavicon(t *testing.T) {
+	tests := []struct {
+		name    string
+		img     image.Image
+		wantErr error
+	}{
+		{name: "Valid size", img: testImage(64)},
+		{name: "Too small", img: testImage(32), wantErr: ErrInvalidFaviconSize},
+		{name: "Too large", img: testImage(128), wantErr: ErrInvalidFaviconSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeFavicon(tt.img)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("EncodeFavicon() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil {
+				if b := decodeFavicon(t, got).Bounds(); b.Dx() != 64 || b.Dy() != 64 {
+					t.Errorf("EncodeFavicon() size = %v, want 64x64", b)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFavicon(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr bool
+	}{
+		{
+			name: "PNG",
+			path: func(t *testing.T) string { return writeTestImage(t, "icon.png", testImage(64)) },
+		},
+		{
+			name: "JPEG is re-encoded",
+			path: func(t *testing.T) string { return writeTestImage(t, "icon.jpg", testImage(64)) },
+		},
+		{
+			name:    "Wrong size",
+			path:    func(t *testing.T) string { return writeTestImage(t, "icon.png", testImage(16)) },
+			wantErr: true,
+		},
+		{
+			name: "Not an image",
+			path: func(t *testing.T) string {
+				path := filepath.Join(t.TempDir(), "icon.png")
+				os.WriteFile(path, []byte("not an image"), 0o644)
+				return path
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Missing file",
+			path:    func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.png") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadFavicon(tt.path(t))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFavicon() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				decodeFavicon(t, got)
+			}
+		})
+	}
+}
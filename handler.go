@@ -0,0 +1,124 @@
+package cobble
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"reflect"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/status"
+)
+
+// Handler responds to a single packet. Returning an error closes the
+// connection.
+type Handler interface {
+	ServePacket(c *Conn, p proto.Packet) error
+}
+
+type HandlerFunc func(c *Conn, p proto.Packet) error
+
+func (f HandlerFunc) ServePacket(c *Conn, p proto.Packet) error {
+	return f(c, p)
+}
+
+// Middleware wraps every handler, including the built-in ones and
+// Server.NotFound. Middleware registered first runs outermost.
+type Middleware func(Handler) Handler
+
+type handlerKey struct {
+	state proto.State
+	id    int32
+}
+
+// defaultHandlers are the built-in handlers, keyed by message type so
+// that packet IDs are resolved through the registry for the client's
+// protocol version.
+var defaultHandlers = map[reflect.Type]HandlerFunc{
+	reflect.TypeFor[*handshaking.Handshake]():    handleHandshake,
+	reflect.TypeFor[*status.StatusRequest]():     handleStatusRequest,
+	reflect.TypeFor[*status.PingRequest]():       handlePingRequest,
+	reflect.TypeFor[*login.LoginStart]():         handleLoginStart,
+	reflect.TypeFor[*login.EncryptionResponse](): handleEncryptionResponse,
+	reflect.TypeFor[*login.LoginAcknowledged]():  handleLoginAcknowledged,
+
+	reflect.TypeFor[*configuration.ClientInformation]():              handleClientInformation,
+	reflect.TypeFor[*configuration.ServerboundPluginMessage]():       handlePluginMessage,
+	reflect.TypeFor[*configuration.ServerboundKnownPacks]():          handleKnownPacks,
+	reflect.TypeFor[*configuration.ServerboundKeepAlive]():           handleConfigurationKeepAlive,
+	reflect.TypeFor[*configuration.AcknowledgeFinishConfiguration](): handleAcknowledgeFinishConfiguration,
+
+	reflect.TypeFor[*play.ConfirmTeleportation](): handleConfirmTeleportation,
+	reflect.TypeFor[*play.ServerboundKeepAlive](): handlePlayKeepAlive,
+}
+
+// Handle registers h for packets with the given ID in the given state,
+// replacing any built-in handling of that packet.
+func (s *Server) Handle(state proto.State, id int32, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.handlers == nil {
+		s.handlers = make(map[handlerKey]Handler)
+	}
+	s.handlers[handlerKey{state, id}] = h
+}
+
+func (s *Server) HandleFunc(state proto.State, id int32, f func(c *Conn, p proto.Packet) error) {
+	s.Handle(state, id, HandlerFunc(f))
+}
+
+func (s *Server) Use(mw ...Middleware) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.middleware = append(s.middleware, mw...)
+}
+
+func (s *Server) handler(c *Conn, id int32) Handler {
+	var builtin HandlerFunc
+	if m, err := s.registry().New(c.protocol(), c.State(), proto.Serverbound, id); err == nil {
+		builtin = defaultHandlers[reflect.TypeOf(m)]
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	h, ok := s.handlers[handlerKey{c.State(), id}]
+	if !ok {
+		if builtin != nil {
+			h = builtin
+		} else if s.NotFound != nil {
+			h = s.NotFound
+		} else {
+			h = HandlerFunc(logUnknownPacket)
+		}
+	}
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		h = s.middleware[i](h)
+	}
+	return h
+}
+
+func logUnknownPacket(c *Conn, p proto.Packet) error {
+	// Clients send movement and other routine packets many times a
+	// second in the play state, most of which cobble does not handle.
+	if c.State() == proto.StatePlay {
+		return nil
+	}
+	err := &proto.UnknownPacketError{
+		Version:   c.protocol(),
+		State:     c.State(),
+		Direction: proto.Serverbound,
+		ID:        p.ID,
+	}
+	log.Printf("Ignoring packet from %s: %v\n", c.RemoteAddr(), err)
+	return nil
+}
+
+func readPacket(p proto.Packet, v io.ReaderFrom) error {
+	_, err := v.ReadFrom(bytes.NewReader(p.Data))
+	return err
+}
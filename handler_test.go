@@ -0,0 +1,121 @@
+package cobble
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/status"
+)
+
+func startStatus(t *testing.T, c *proto.Conn) {
+	t.Helper()
+	writeTestPacket(t, c, handshaking.HandshakeID, &handshaking.Handshake{ProtocolVersion: 768, ServerAddress: "localhost", ServerPort: 25565, NextState: 1})
+}
+
+func TestServer_Handle(t *testing.T) {
+	s := &Server{}
+	s.HandleFunc(proto.StateStatus, status.StatusRequestID, func(c *Conn, p proto.Packet) error {
+		return c.WritePacket(status.StatusResponseID, &status.StatusResponse{JSONResponse: c.Handshake.ServerAddress})
+	})
+	c := dialTest(t, s)
+	startStatus(t, c)
+	writeTestPacket(t, c, status.StatusRequestID, &status.StatusRequest{})
+
+	var res status.StatusResponse
+	readTestPacket(t, c, status.StatusResponseID, &res)
+	if res.JSONResponse != "localhost" {
+		t.Errorf("StatusResponse.JSONResponse = %v, want localhost", res.JSONResponse)
+	}
+}
+
+func TestServer_Use(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(c *Conn, p proto.Packet) error {
+				calls = append(calls, name+":"+c.State().String())
+				return next.ServePacket(c, p)
+			})
+		}
+	}
+
+	s := &Server{}
+	s.Use(trace("outer"), trace("inner"))
+	c := dialTest(t, s)
+	startStatus(t, c)
+	writeTestPacket(t, c, status.PingRequestID, &status.PingRequest{Payload: 7})
+
+	var res status.PingResponse
+	readTestPacket(t, c, status.PingResponseID, &res)
+	if res.Payload != 7 {
+		t.Errorf("PingResponse.Payload = %v, want 7", res.Payload)
+	}
+	want := []string{"outer:handshaking", "inner:handshaking", "outer:status", "inner:status"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("middleware calls = %v, want %v", calls, want)
+	}
+}
+
+func TestServer_NotFound(t *testing.T) {
+	errUnknown := errors.New("unknown packet")
+	got := make(chan proto.Packet, 1)
+	s := &Server{NotFound: HandlerFunc(func(c *Conn, p proto.Packet) error {
+		got <- p
+		return errUnknown
+	})}
+	c := dialTest(t, s)
+	startStatus(t, c)
+	writeTestPacket(t, c, 0x7F, bytes.NewBufferString("data"))
+
+	want := proto.Packet{ID: 0x7F, Data: []byte("data")}
+	if p := <-got; !reflect.DeepEqual(p, want) {
+		t.Errorf("NotFound packet = %v, want %v", p, want)
+	}
+	if _, err := c.ReadPacket(); err == nil {
+		t.Errorf("Conn.ReadPacket() error = nil, want closed connection")
+	}
+}
+
+func TestServer_versionedHandlers(t *testing.T) {
+	// Protocol 769 swaps the IDs of the serverbound status packets.
+	r := proto.NewRegistry()
+	for _, v := range proto.Versions {
+		requestID, pingID := int32(status.StatusRequestID), int32(status.PingRequestID)
+		if v.Protocol == 769 {
+			requestID, pingID = pingID, requestID
+		}
+		r.Register(v.Protocol, proto.StateHandshaking, proto.Serverbound, handshaking.HandshakeID, func() proto.Message { return new(handshaking.Handshake) })
+		r.Register(v.Protocol, proto.StateStatus, proto.Serverbound, requestID, func() proto.Message { return new(status.StatusRequest) })
+		r.Register(v.Protocol, proto.StateStatus, proto.Serverbound, pingID, func() proto.Message { return new(status.PingRequest) })
+		r.Register(v.Protocol, proto.StateStatus, proto.Clientbound, status.StatusResponseID, func() proto.Message { return new(status.StatusResponse) })
+		r.Register(v.Protocol, proto.StateStatus, proto.Clientbound, status.PingResponseID, func() proto.Message { return new(status.PingResponse) })
+	}
+
+	tests := []struct {
+		protocol  int32
+		requestID int32
+		pingID    int32
+	}{
+		{protocol: 768, requestID: status.StatusRequestID, pingID: status.PingRequestID},
+		{protocol: 769, requestID: status.PingRequestID, pingID: status.StatusRequestID},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(int(tt.protocol)), func(t *testing.T) {
+			c := dialTest(t, &Server{Registry: r})
+			writeTestPacket(t, c, handshaking.HandshakeID, &handshaking.Handshake{ProtocolVersion: tt.protocol, ServerAddress: "localhost", ServerPort: 25565, NextState: 1})
+			writeTestPacket(t, c, tt.requestID, &status.StatusRequest{})
+			readTestPacket(t, c, status.StatusResponseID, &status.StatusResponse{})
+			writeTestPacket(t, c, tt.pingID, &status.PingRequest{Payload: 7})
+			var res status.PingResponse
+			readTestPacket(t, c, status.PingResponseID, &res)
+			if res.Payload != 7 {
+				t.Errorf("PingResponse.Payload = %v, want 7", res.Payload)
+			}
+		})
+	}
+}
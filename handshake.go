@@ -0,0 +1,42 @@
+package cobble
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+var errTransfersDisabled = errors.New("transfers are disabled")
+
+func handleHandshake(c *Conn, p proto.Packet) error {
+	if err := readPacket(p, &c.Handshake); err != nil {
+		return err
+	}
+	switch c.Handshake.NextState {
+	case 1:
+		c.SetState(proto.StateStatus)
+	case 2, 3:
+		c.SetState(proto.StateLogin)
+		if _, ok := c.Version(); !ok {
+			c.Disconnect(unsupportedVersionMessage(c.Handshake.ProtocolVersion))
+			return fmt.Errorf("unsupported protocol version %d", c.Handshake.ProtocolVersion)
+		}
+		if c.Handshake.NextState == 3 && !c.Server.AcceptTransfers {
+			c.Disconnect(text.Translatable("multiplayer.disconnect.transfers_disabled"))
+			return errTransfersDisabled
+		}
+	default:
+		return fmt.Errorf("invalid next state %v", c.Handshake.NextState)
+	}
+	return nil
+}
+
+func unsupportedVersionMessage(protocol int32) text.Component {
+	key := "multiplayer.disconnect.outdated_client"
+	if protocol > proto.LatestVersion.Protocol {
+		key = "multiplayer.disconnect.outdated_server"
+	}
+	return text.Translatable(key, text.Plain(proto.VersionRange()))
+}
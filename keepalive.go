@@ -0,0 +1,138 @@
+package cobble
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+const (
+	keepAliveInterval = 15 * time.Second
+	keepAliveTimeout  = 30 * time.Second
+)
+
+var errKeepAliveMismatch = errors.New("keep-alive reply does not match")
+
+// Latency returns the round-trip time measured with keep-alives, averaged
+// the way vanilla servers do for the player list. It is zero until the
+// client answers the first keep-alive.
+func (c *Conn) Latency() time.Duration {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	return c.latency
+}
+
+// keepAlive sends a keep-alive every interval while the connection is in
+// the configuration or play state. A keep-alive is not sent while the
+// previous one is unanswered, and the client is disconnected once it has
+// not answered for the timeout.
+func (c *Conn) keepAlive() {
+	interval, timeout := c.Server.keepAliveDurations()
+
+	c.keepAliveMu.Lock()
+	c.lastKeepAlive = time.Now()
+	c.keepAliveMu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case now := <-ticker.C:
+			if err := c.sendKeepAlive(now); err != nil {
+				// The writer may have timed out mid-packet, so the
+				// connection cannot be used any more.
+				c.Close()
+				return
+			}
+		case now := <-timer.C:
+			c.keepAliveMu.Lock()
+			remaining := timeout - now.Sub(c.lastKeepAlive)
+			c.keepAliveMu.Unlock()
+			if remaining > 0 {
+				timer.Reset(remaining)
+				continue
+			}
+			log.Printf("Closing connection from %s: keep-alive timed out\n", c.RemoteAddr())
+			c.Disconnect(text.Translatable("disconnect.timeout"))
+			c.Close()
+			return
+		}
+	}
+}
+
+// setKeepAliveState sets the state whose keep-alive is sent. Keep-alives
+// are paused in any other state, such as while the client switches from
+// configuration to play.
+func (c *Conn) setKeepAliveState(state proto.State) {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	c.keepAliveState = state
+}
+
+func (c *Conn) sendKeepAlive(now time.Time) error {
+	c.keepAliveMu.Lock()
+	defer c.keepAliveMu.Unlock()
+	if c.keepAlivePending {
+		return nil
+	}
+
+	id := now.UnixMilli()
+	var m proto.Message
+	switch c.keepAliveState {
+	case proto.StateConfiguration:
+		m = &configuration.ClientboundKeepAlive{ID: id}
+	case proto.StatePlay:
+		m = &play.ClientboundKeepAlive{ID: id}
+	default:
+		return nil
+	}
+	if err := c.writeMessage(c.keepAliveState, m); err != nil {
+		return err
+	}
+	c.keepAlivePending = true
+	c.keepAliveID = id
+	c.keepAliveSent = now
+	return nil
+}
+
+// keepAliveReceived checks a keep-alive reply against the one awaited.
+// Replies that do not match disconnect the client, as in vanilla.
+func (c *Conn) keepAliveReceived(id int64) error {
+	c.keepAliveMu.Lock()
+	if !c.keepAlivePending || id != c.keepAliveID {
+		c.keepAliveMu.Unlock()
+		c.Disconnect(text.Translatable("disconnect.timeout"))
+		return errKeepAliveMismatch
+	}
+	now := time.Now()
+	c.keepAlivePending = false
+	c.lastKeepAlive = now
+	c.latency = (c.latency*3 + now.Sub(c.keepAliveSent)) / 4
+	c.keepAliveMu.Unlock()
+	return nil
+}
+
+func handleConfigurationKeepAlive(c *Conn, p proto.Packet) error {
+	var res configuration.ServerboundKeepAlive
+	if err := readPacket(p, &res); err != nil {
+		return err
+	}
+	return c.keepAliveReceived(res.ID)
+}
+
+func handlePlayKeepAlive(c *Conn, p proto.Packet) error {
+	var res play.ServerboundKeepAlive
+	if err := readPacket(p, &res); err != nil {
+		return err
+	}
+	return c.keepAliveReceived(res.ID)
+}
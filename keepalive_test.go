@@ -0,0 +1,101 @@
+package cobble
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func TestServer_keepAlive(t *testing.T) {
+	latency := make(chan time.Duration, 1)
+	s := &Server{keepAliveInterval: 50 * time.Millisecond}
+	s.HandleFunc(proto.StateConfiguration, 0x7F, func(c *Conn, p proto.Packet) error {
+		latency <- c.Latency()
+		return nil
+	})
+	c := dialTest(t, s)
+	startConfiguration(t, c)
+
+	var req configuration.ClientboundKeepAlive
+	readTestPacket(t, c, configuration.ClientboundKeepAliveID, &req)
+	time.Sleep(10 * time.Millisecond)
+	writeTestPacket(t, c, configuration.ServerboundKeepAliveID, &configuration.ServerboundKeepAlive{ID: req.ID})
+	writeTestPacket(t, c, 0x7F, &configuration.AcknowledgeFinishConfiguration{})
+	if got := <-latency; got < 10*time.Millisecond/4 {
+		t.Errorf("Conn.Latency() = %v, want at least a quarter of 10ms", got)
+	}
+
+	// The next keep-alive is sent once the first has been answered.
+	var next configuration.ClientboundKeepAlive
+	readTestPacket(t, c, configuration.ClientboundKeepAliveID, &next)
+	if next.ID == req.ID {
+		t.Errorf("ClientboundKeepAlive.ID = %v, want a new ID", next.ID)
+	}
+}
+
+func TestServer_keepAlivePlay(t *testing.T) {
+	c := dialTest(t, &Server{keepAliveInterval: 50 * time.Millisecond})
+	startPlay(t, c)
+	readJoin(t, c)
+
+	for range 2 {
+		var req play.ClientboundKeepAlive
+		readTestPacket(t, c, play.ClientboundKeepAliveID, &req)
+		writeTestPacket(t, c, play.ServerboundKeepAliveID, &play.ServerboundKeepAlive{ID: req.ID})
+	}
+}
+
+func TestServer_keepAliveMismatch(t *testing.T) {
+	tests := []struct {
+		name string
+		wait bool
+	}{
+		{name: "unsolicited"},
+		{name: "wrong ID", wait: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{keepAliveInterval: 50 * time.Millisecond})
+			startConfiguration(t, c)
+
+			id := int64(1)
+			if tt.wait {
+				var req configuration.ClientboundKeepAlive
+				readTestPacket(t, c, configuration.ClientboundKeepAliveID, &req)
+				id = req.ID + 1
+			}
+			writeTestPacket(t, c, configuration.ServerboundKeepAliveID, &configuration.ServerboundKeepAlive{ID: id})
+
+			var res configuration.Disconnect
+			readTestPacket(t, c, configuration.DisconnectID, &res)
+			if want := text.Translatable("disconnect.timeout"); !reflect.DeepEqual(res.Reason, want) {
+				t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+			}
+		})
+	}
+}
+
+func TestServer_keepAliveTimeout(t *testing.T) {
+	start := time.Now()
+	c := dialTest(t, &Server{keepAliveInterval: 20 * time.Millisecond, keepAliveTimeout: 100 * time.Millisecond})
+	startConfiguration(t, c)
+
+	var req configuration.ClientboundKeepAlive
+	readTestPacket(t, c, configuration.ClientboundKeepAliveID, &req)
+	var res configuration.Disconnect
+	readTestPacket(t, c, configuration.DisconnectID, &res)
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("disconnected after %v, want at least 100ms", elapsed)
+	}
+	if want := text.Translatable("disconnect.timeout"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+	if _, err := c.ReadPacket(); err == nil {
+		t.Errorf("Conn.ReadPacket() error = nil, want the connection closed")
+	}
+}
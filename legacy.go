@@ -0,0 +1,42 @@
+package cobble
+
+import (
+	"github.com/nonya123456/cobble/proto/handshaking"
+)
+
+// isLegacyPing reports whether the connection opened with the pre-1.7
+// server list ping instead of a Handshake packet.
+func isLegacyPing(c *Conn) bool {
+	b, err := c.Peek(1)
+	return err == nil && b[0] == handshaking.LegacyPingID
+}
+
+func handleLegacyPing(c *Conn) error {
+	var ping handshaking.LegacyPing
+	if _, err := ping.ReadFrom(c); err != nil {
+		return err
+	}
+	// 1.6 clients follow up with the address they connected to, which the
+	// status provider may use for virtual hosting.
+	if c.Buffered() > 0 {
+		var host handshaking.LegacyPingHost
+		if _, err := host.ReadFrom(c); err != nil {
+			return err
+		}
+		c.Handshake.ServerAddress = host.ServerAddress
+		c.Handshake.ServerPort = uint16(host.ServerPort)
+	}
+
+	status := c.Server.status(c)
+	res := handshaking.LegacyPingResponse{
+		ProtocolVersion: status.Version.Protocol,
+		Version:         status.Version.Name,
+		MOTD:            status.Description.Legacy(),
+	}
+	if status.Players != nil {
+		res.Online = status.Players.Online
+		res.Max = status.Players.Max
+	}
+	_, err := res.WriteTo(c)
+	return err
+}
@@ -0,0 +1,60 @@
+package cobble
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/status"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func TestServer_legacyPing(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(conn net.Conn)
+		want  handshaking.LegacyPingResponse
+	}{
+		{
+			name: "1.4 ping",
+			write: func(conn net.Conn) {
+				(&handshaking.LegacyPing{}).WriteTo(conn)
+			},
+			want: handshaking.LegacyPingResponse{ProtocolVersion: 769, Version: "1.21.3-1.21.4", MOTD: "Welcome to ", Online: 2, Max: 10},
+		},
+		{
+			name: "1.6 ping with host",
+			write: func(conn net.Conn) {
+				buf := bytes.NewBuffer([]byte{handshaking.LegacyPingID, handshaking.LegacyPingPayload})
+				host := &handshaking.LegacyPingHost{ProtocolVersion: 78, ServerAddress: "play.example.com", ServerPort: 25565}
+				host.WriteTo(buf)
+				conn.Write(buf.Bytes())
+			},
+			want: handshaking.LegacyPingResponse{ProtocolVersion: 769, Version: "1.21.3-1.21.4", MOTD: "Welcome to play.example.com", Online: 2, Max: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{StatusProvider: func(c *Conn) status.Response {
+				return status.Response{
+					Players:     &status.Players{Max: 10, Online: 2},
+					Description: text.Plain("Welcome to " + c.Handshake.ServerAddress),
+				}
+			}}
+			client, conn := net.Pipe()
+			defer client.Close()
+			go s.handle(conn)
+			go tt.write(client)
+
+			var res handshaking.LegacyPingResponse
+			if _, err := res.ReadFrom(client); err != nil {
+				t.Fatalf("LegacyPingResponse.ReadFrom() error = %v", err)
+			}
+			if !reflect.DeepEqual(res, tt.want) {
+				t.Errorf("LegacyPingResponse = %v, want %v", res, tt.want)
+			}
+		})
+	}
+}
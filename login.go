@@ -0,0 +1,182 @@
+package cobble
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/nonya123456/cobble/auth"
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+const sessionTimeout = 10 * time.Second
+
+var (
+	errInvalidUsername     = errors.New("invalid username")
+	errInvalidVerifyToken  = errors.New("invalid verify token")
+	errInvalidSharedSecret = errors.New("invalid shared secret")
+	errUnexpectedPacket    = errors.New("unexpected packet")
+)
+
+func handleLoginStart(c *Conn, p proto.Packet) error {
+	var start login.LoginStart
+	if err := readPacket(p, &start); err != nil {
+		return err
+	}
+	if c.loginName != "" {
+		return errUnexpectedPacket
+	}
+	if !validUsername(start.Name) {
+		c.Disconnect(text.Plain("Invalid username"))
+		return errInvalidUsername
+	}
+	if !c.Server.OnlineMode {
+		c.loginName = start.Name
+		return c.loginSuccess(login.LoginSuccess{UUID: login.OfflineUUID(start.Name), Username: start.Name})
+	}
+
+	key, err := c.Server.keyPair()
+	if err != nil {
+		c.Disconnect(text.Plain("Internal server error"))
+		return err
+	}
+	c.loginName = start.Name
+	c.verifyToken = make([]byte, 4)
+	if _, err := rand.Read(c.verifyToken); err != nil {
+		return err
+	}
+	req := login.EncryptionRequest{PublicKey: key.PublicKey, VerifyToken: c.verifyToken, ShouldAuthenticate: true}
+	return c.WriteMessage(&req)
+}
+
+func handleEncryptionResponse(c *Conn, p proto.Packet) error {
+	var res login.EncryptionResponse
+	if err := readPacket(p, &res); err != nil {
+		return err
+	}
+	verifyToken := c.verifyToken
+	if verifyToken == nil {
+		return errUnexpectedPacket
+	}
+	c.verifyToken = nil
+	sharedSecret, err := c.Server.decryptSharedSecret(verifyToken, res)
+	if err != nil {
+		c.Disconnect(text.Plain("Failed to verify username!"))
+		return err
+	}
+	// The client encrypts from its response on, so everything after it,
+	// including a failure to authenticate, has to be encrypted too.
+	if err := c.EnableEncryption(sharedSecret); err != nil {
+		return err
+	}
+	profile, err := c.Server.authenticate(c.loginName, sharedSecret)
+	if err != nil {
+		c.Disconnect(text.Plain("Failed to verify username!"))
+		return err
+	}
+	return c.loginSuccess(*profile)
+}
+
+func handleLoginAcknowledged(c *Conn, p proto.Packet) error {
+	var ack login.LoginAcknowledged
+	if err := readPacket(p, &ack); err != nil {
+		return err
+	}
+	if !c.loggedIn {
+		return errUnexpectedPacket
+	}
+	c.SetState(proto.StateConfiguration)
+	return c.startConfiguration()
+}
+
+// decryptSharedSecret checks the verify token of res and returns its
+// shared secret.
+func (s *Server) decryptSharedSecret(verifyToken []byte, res login.EncryptionResponse) ([]byte, error) {
+	key, err := s.keyPair()
+	if err != nil {
+		return nil, err
+	}
+	token, err := key.Decrypt(res.VerifyToken)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(token, verifyToken) {
+		return nil, errInvalidVerifyToken
+	}
+	sharedSecret, err := key.Decrypt(res.SharedSecret)
+	if err != nil {
+		return nil, err
+	}
+	if len(sharedSecret) != 16 {
+		return nil, errInvalidSharedSecret
+	}
+	return sharedSecret, nil
+}
+
+// authenticate asks the session server whether username has joined with
+// sharedSecret.
+func (s *Server) authenticate(username string, sharedSecret []byte) (*login.LoginSuccess, error) {
+	key, err := s.keyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
+	defer cancel()
+	serverHash := auth.ServerHash("", sharedSecret, key.PublicKey)
+	profile, err := auth.HasJoined(ctx, http.DefaultClient, s.sessionServer(), username, serverHash, "")
+	if err != nil {
+		return nil, err
+	}
+	uuid, err := profile.UUID()
+	if err != nil {
+		return nil, err
+	}
+
+	success := &login.LoginSuccess{UUID: uuid, Username: profile.Name}
+	for _, property := range profile.Properties {
+		success.Properties = append(success.Properties, login.Property{
+			Name:      property.Name,
+			Value:     property.Value,
+			Signature: property.Signature,
+		})
+	}
+	return success, nil
+}
+
+func (c *Conn) loginSuccess(res login.LoginSuccess) error {
+	if threshold := c.Server.CompressionThreshold; threshold > 0 {
+		req := login.SetCompression{Threshold: int32(threshold)}
+		if err := c.WriteMessage(&req); err != nil {
+			return err
+		}
+		c.SetCompressionThreshold(threshold)
+	}
+	if err := c.WriteMessage(&res); err != nil {
+		return err
+	}
+	c.loggedIn = true
+	c.Username = res.Username
+	c.UUID = res.UUID
+	c.Properties = res.Properties
+	log.Printf("%s (%s) logged in from %s\n", res.Username, res.UUID, c.RemoteAddr())
+	return nil
+}
+
+func validUsername(name string) bool {
+	if len(name) == 0 || len(name) > login.MaxUsernameLength {
+		return false
+	}
+	for _, c := range name {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
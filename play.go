@@ -0,0 +1,87 @@
+package cobble
+
+import (
+	"fmt"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/types"
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+const (
+	spawnDimension   types.Identifier = "minecraft:overworld"
+	spawnBiome       types.Identifier = "minecraft:the_void"
+	spawnChunkRadius                  = 2
+	spawnY                            = 64
+)
+
+// join spawns the player in an empty world. With nothing to stand on, the
+// player is put in spectator mode.
+func (c *Conn) join() error {
+	dimensionTypes := c.registryData.Registry("minecraft:dimension_type")
+	biomes := c.registryData.Registry("minecraft:worldgen/biome")
+	if dimensionTypes == nil || biomes == nil {
+		return fmt.Errorf("registry data lacks dimension types or biomes")
+	}
+	dimensionType := dimensionTypes.Index(spawnDimension)
+	biome := biomes.Index(spawnBiome)
+	if dimensionType < 0 || biome < 0 {
+		return fmt.Errorf("registry data lacks %s or %s", spawnDimension, spawnBiome)
+	}
+	entry, _ := dimensionTypes.Lookup(spawnDimension)
+	sections := dimensionHeight(entry) / 16
+
+	login := play.Login{
+		EntityID:            1,
+		DimensionNames:      []types.Identifier{spawnDimension},
+		MaxPlayers:          20,
+		ViewDistance:        spawnChunkRadius,
+		SimulationDistance:  spawnChunkRadius,
+		EnableRespawnScreen: true,
+		DimensionType:       int32(dimensionType),
+		DimensionName:       spawnDimension,
+		GameMode:            play.GameModeSpectator,
+		PreviousGameMode:    -1,
+		SeaLevel:            63,
+	}
+	if err := c.WriteMessage(&login); err != nil {
+		return err
+	}
+	c.setKeepAliveState(proto.StatePlay)
+	if err := c.WriteMessage(&play.SynchronizePlayerPosition{TeleportID: 1, X: 0.5, Y: spawnY, Z: 0.5}); err != nil {
+		return err
+	}
+	if err := c.WriteMessage(&play.GameEvent{Event: play.GameEventStartWaitingForChunks}); err != nil {
+		return err
+	}
+	if err := c.WriteMessage(&play.SetCenterChunk{}); err != nil {
+		return err
+	}
+	data := play.EmptyChunkSections(int(sections), int32(biome))
+	for x := int32(-spawnChunkRadius); x <= spawnChunkRadius; x++ {
+		for z := int32(-spawnChunkRadius); z <= spawnChunkRadius; z++ {
+			chunk := play.ChunkDataAndUpdateLight{ChunkX: x, ChunkZ: z, Heightmaps: map[string]any{}, Data: data}
+			if err := c.WriteMessage(&chunk); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dimensionHeight returns the height of a dimension type, falling back to
+// that of the vanilla overworld when the entry has no contents.
+func dimensionHeight(e vanilla.Entry) int32 {
+	if data, ok := e.Data.(map[string]any); ok {
+		if height, ok := data["height"].(int32); ok && height > 0 {
+			return height
+		}
+	}
+	return 384
+}
+
+func handleConfirmTeleportation(c *Conn, p proto.Packet) error {
+	var confirm play.ConfirmTeleportation
+	return readPacket(p, &confirm)
+}
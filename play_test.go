@@ -0,0 +1,110 @@
+package cobble
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+// startPlay logs in and completes configuration as a client with the
+// vanilla pack.
+func startPlay(t *testing.T, c *proto.Conn) {
+	t.Helper()
+	packs := startConfiguration(t, c)
+	writeTestPacket(t, c, configuration.ServerboundKnownPacksID, &configuration.ServerboundKnownPacks{Packs: packs})
+	for {
+		packet, err := c.ReadPacket()
+		if err != nil {
+			t.Fatalf("Conn.ReadPacket() error = %v", err)
+		}
+		if packet.ID == configuration.FinishConfigurationID {
+			break
+		}
+	}
+	writeTestPacket(t, c, configuration.AcknowledgeFinishConfigurationID, &configuration.AcknowledgeFinishConfiguration{})
+}
+
+// readJoin reads the packets that spawn the player and returns the Login
+// packet.
+func readJoin(t *testing.T, c *proto.Conn) play.Login {
+	t.Helper()
+	var login play.Login
+	readTestPacket(t, c, play.LoginID, &login)
+	readTestPacket(t, c, play.SynchronizePlayerPositionID, &play.SynchronizePlayerPosition{})
+	readTestPacket(t, c, play.GameEventID, &play.GameEvent{})
+	readTestPacket(t, c, play.SetCenterChunkID, &play.SetCenterChunk{})
+	for range (2*spawnChunkRadius + 1) * (2*spawnChunkRadius + 1) {
+		readTestPacket(t, c, play.ChunkDataAndUpdateLightID, &play.ChunkDataAndUpdateLight{})
+	}
+	return login
+}
+
+func TestServer_join(t *testing.T) {
+	c := dialTest(t, &Server{})
+	startPlay(t, c)
+
+	data, err := vanilla.Load(768)
+	if err != nil {
+		t.Fatalf("vanilla.Load() error = %v", err)
+	}
+	biome := int32(data.Registry("minecraft:worldgen/biome").Index("minecraft:the_void"))
+
+	var login play.Login
+	readTestPacket(t, c, play.LoginID, &login)
+	if want := int32(data.Registry("minecraft:dimension_type").Index("minecraft:overworld")); login.DimensionType != want || login.DimensionName != "minecraft:overworld" {
+		t.Errorf("Login dimension = %v (%v), want minecraft:overworld (%v)", login.DimensionName, login.DimensionType, want)
+	}
+	if login.GameMode != play.GameModeSpectator {
+		t.Errorf("Login.GameMode = %v, want %v", login.GameMode, play.GameModeSpectator)
+	}
+
+	var position play.SynchronizePlayerPosition
+	readTestPacket(t, c, play.SynchronizePlayerPositionID, &position)
+	if want := (play.SynchronizePlayerPosition{TeleportID: 1, X: 0.5, Y: spawnY, Z: 0.5}); position != want {
+		t.Errorf("SynchronizePlayerPosition = %v, want %v", position, want)
+	}
+	var event play.GameEvent
+	readTestPacket(t, c, play.GameEventID, &event)
+	if event.Event != play.GameEventStartWaitingForChunks {
+		t.Errorf("GameEvent.Event = %v, want %v", event.Event, play.GameEventStartWaitingForChunks)
+	}
+	var center play.SetCenterChunk
+	readTestPacket(t, c, play.SetCenterChunkID, &center)
+	if center != (play.SetCenterChunk{}) {
+		t.Errorf("SetCenterChunk = %v, want chunk 0, 0", center)
+	}
+
+	seen := make(map[[2]int32]bool)
+	for range (2*spawnChunkRadius + 1) * (2*spawnChunkRadius + 1) {
+		var chunk play.ChunkDataAndUpdateLight
+		readTestPacket(t, c, play.ChunkDataAndUpdateLightID, &chunk)
+		seen[[2]int32{chunk.ChunkX, chunk.ChunkZ}] = true
+		if want := play.EmptyChunkSections(24, biome); !reflect.DeepEqual(chunk.Data, want) {
+			t.Errorf("chunk %v, %v data = %v, want %v", chunk.ChunkX, chunk.ChunkZ, chunk.Data, want)
+		}
+	}
+	if !seen[[2]int32{0, 0}] || !seen[[2]int32{-spawnChunkRadius, spawnChunkRadius}] {
+		t.Errorf("chunks sent = %v, want every chunk within %v of spawn", seen, spawnChunkRadius)
+	}
+
+	writeTestPacket(t, c, play.ConfirmTeleportationID, &play.ConfirmTeleportation{TeleportID: 1})
+}
+
+func TestServer_playDisconnect(t *testing.T) {
+	s := &Server{ShutdownMessage: "Restarting"}
+	c := dialTest(t, s)
+	startPlay(t, c)
+	readJoin(t, c)
+
+	go s.close()
+	var res play.Disconnect
+	readTestPacket(t, c, play.DisconnectID, &res)
+	if want := text.Plain("Restarting"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+}
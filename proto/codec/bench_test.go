@@ -0,0 +1,101 @@
+package codec_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/stream"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+// handwrittenHandshake is the handshake as it was written before the
+// codec, kept as a baseline.
+type handwrittenHandshake struct {
+	ProtocolVersion int32
+	ServerAddress   string
+	ServerPort      uint16
+	NextState       int32
+}
+
+func (h *handwrittenHandshake) ReadFrom(r io.Reader) (int64, error) {
+	var protocolVersion types.VarInt
+	var serverAddress types.String
+	var serverPort types.UnsignedShort
+	var nextState types.VarInt
+
+	n, err := stream.ReadAll(r, &protocolVersion, types.LimitString(&serverAddress, 255), &serverPort, &nextState)
+	if err != nil {
+		return n, err
+	}
+
+	h.ProtocolVersion = int32(protocolVersion)
+	h.ServerAddress = string(serverAddress)
+	h.ServerPort = uint16(serverPort)
+	h.NextState = int32(nextState)
+	return n, nil
+}
+
+func (h *handwrittenHandshake) WriteTo(w io.Writer) (int64, error) {
+	protocolVersion := types.VarInt(h.ProtocolVersion)
+	serverAddress := types.String(h.ServerAddress)
+	serverPort := types.UnsignedShort(h.ServerPort)
+	nextState := types.VarInt(h.NextState)
+	return stream.WriteAll(w, &protocolVersion, &serverAddress, &serverPort, &nextState)
+}
+
+type taggedHandshake struct {
+	ProtocolVersion int32  `mc:"varint"`
+	ServerAddress   string `mc:"string,max=255"`
+	ServerPort      uint16
+	NextState       int32 `mc:"varint"`
+}
+
+var benchHandshake = []byte{0x80, 0x06, 0x09, 'l', 'o', 'c', 'a', 'l', 'h', 'o', 's', 't', 0x63, 0xDD, 0x02}
+
+func BenchmarkRead(b *testing.B) {
+	b.Run("Handwritten", func(b *testing.B) {
+		r := bytes.NewReader(benchHandshake)
+		for range b.N {
+			r.Reset(benchHandshake)
+			var h handwrittenHandshake
+			if _, err := h.ReadFrom(r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Codec", func(b *testing.B) {
+		r := bytes.NewReader(benchHandshake)
+		for range b.N {
+			r.Reset(benchHandshake)
+			var h taggedHandshake
+			if _, err := codec.Read(r, &h); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkWrite(b *testing.B) {
+	b.Run("Handwritten", func(b *testing.B) {
+		h := handwrittenHandshake{ProtocolVersion: 768, ServerAddress: "localhost", ServerPort: 25565, NextState: 2}
+		var buf bytes.Buffer
+		for range b.N {
+			buf.Reset()
+			if _, err := h.WriteTo(&buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Codec", func(b *testing.B) {
+		h := taggedHandshake{ProtocolVersion: 768, ServerAddress: "localhost", ServerPort: 25565, NextState: 2}
+		var buf bytes.Buffer
+		for range b.N {
+			buf.Reset()
+			if _, err := codec.Write(&buf, &h); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
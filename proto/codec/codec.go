@@ -0,0 +1,208 @@
+// Package codec reads and writes packets declared as plain structs.
+//
+// Fields are encoded in order. Their protocol type is inferred from the Go
+// type: bool, int8, uint8, int16, uint16, int32, int64, float32, float64,
+// string, []byte (VarInt-prefixed), [N]byte, nested structs, and any type
+// whose pointer implements io.ReaderFrom and io.WriterTo, such as
+// types.UUID or text.Component. An `mc` tag overrides the inference:
+//
+//	Count    int32          `mc:"varint"`
+//	Address  string         `mc:"string,max=255"`
+//	Token    []byte         `mc:"bytearray,max=4"`
+//	Reason   text.Component `mc:"json"`
+//	Data     map[string]any `mc:"nbt"`
+//	Payload  []byte         `mc:"rest"`
+//	Sig      *string        `mc:"optional"`
+//	Entries  []Entry        `mc:"array,prefix=varint"`
+//	Skipped  int            `mc:"-"`
+//
+// The kinds are varint, varlong, string, identifier, bytearray, json, nbt
+// and rest. varint applies to int32 fields and varlong to int64 fields.
+// JSON strings are limited to text.MaxJSONLength unless a max is
+// given. "optional" prefixes the field with a Boolean; a nil pointer or
+// zero value is sent as absent. "array" encodes a slice with a count
+// prefix of varint (the default) or int, or none to read
+// elements until the end of the packet; other options apply to each
+// element.
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidLength = errors.New("codec: invalid length")
+	ErrTooLong       = errors.New("codec: value is too long")
+)
+
+// Read decodes the fields of the struct pointed to by v from r. v is left
+// unchanged if an error occurs.
+func Read(r io.Reader, v any) (int64, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return 0, errors.New("codec: Read requires a non-nil pointer")
+	}
+	c, err := cachedCoder(rv.Type().Elem())
+	if err != nil {
+		return 0, err
+	}
+	decoded := reflect.New(rv.Type().Elem()).Elem()
+	n, err := c.read(r, decoded)
+	if err != nil {
+		return n, err
+	}
+	rv.Elem().Set(decoded)
+	return n, nil
+}
+
+// Write encodes the fields of the struct v, or the struct it points to,
+// to w.
+func Write(w io.Writer, v any) (int64, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if !rv.CanAddr() {
+		p := reflect.New(rv.Type()).Elem()
+		p.Set(rv)
+		rv = p
+	}
+	c, err := cachedCoder(rv.Type())
+	if err != nil {
+		return 0, err
+	}
+	return c.write(w, rv)
+}
+
+// coder reads into and writes from addressable values of one type.
+type coder struct {
+	read  func(r io.Reader, v reflect.Value) (int64, error)
+	write func(w io.Writer, v reflect.Value) (int64, error)
+}
+
+type cacheEntry struct {
+	coder coder
+	err   error
+}
+
+var coderCache sync.Map
+
+func cachedCoder(t reflect.Type) (coder, error) {
+	if e, ok := coderCache.Load(t); ok {
+		return e.(cacheEntry).coder, e.(cacheEntry).err
+	}
+	if t.Kind() != reflect.Struct {
+		return coder{}, fmt.Errorf("codec: %s is not a struct", t)
+	}
+	c, err := structCoder(t)
+	e, _ := coderCache.LoadOrStore(t, cacheEntry{c, err})
+	return e.(cacheEntry).coder, e.(cacheEntry).err
+}
+
+type options struct {
+	kind     string
+	optional bool
+	array    bool
+	prefix   string
+	max      int
+}
+
+func parseTag(tag string) (options, error) {
+	var opts options
+	if tag == "" {
+		return opts, nil
+	}
+	for _, part := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(part, "=")
+		switch {
+		case key == "optional" && !hasValue:
+			opts.optional = true
+		case key == "array" && !hasValue:
+			opts.array = true
+		case key == "prefix" && hasValue:
+			opts.prefix = value
+		case key == "max" && hasValue:
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("invalid max %q", value)
+			}
+			opts.max = n
+		case !hasValue && opts.kind == "":
+			opts.kind = key
+		default:
+			return opts, fmt.Errorf("invalid option %q", part)
+		}
+	}
+	return opts, nil
+}
+
+func structCoder(t reflect.Type) (coder, error) {
+	type fieldCoder struct {
+		index int
+		coder coder
+	}
+	var fields []fieldCoder
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("mc")
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+		opts, err := parseTag(tag)
+		if err != nil {
+			return coder{}, fmt.Errorf("codec: %s.%s: %w", t, sf.Name, err)
+		}
+		c, err := newCoder(sf.Type, opts)
+		if err != nil {
+			return coder{}, fmt.Errorf("codec: %s.%s: %w", t, sf.Name, err)
+		}
+		fields = append(fields, fieldCoder{i, c})
+	}
+
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var total int64
+			for _, f := range fields {
+				n, err := f.coder.read(r, v.Field(f.index))
+				total += n
+				if err != nil {
+					return total, err
+				}
+			}
+			return total, nil
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			var total int64
+			for _, f := range fields {
+				n, err := f.coder.write(w, v.Field(f.index))
+				total += n
+				if err != nil {
+					return total, err
+				}
+			}
+			return total, nil
+		},
+	}, nil
+}
+
+func newCoder(t reflect.Type, opts options) (coder, error) {
+	switch {
+	case opts.optional:
+		opts.optional = false
+		return optionalCoder(t, opts)
+	case opts.array:
+		opts.array = false
+		return arrayCoder(t, opts)
+	case opts.prefix != "":
+		return coder{}, errors.New("prefix requires the array option")
+	case opts.kind != "":
+		return kindCoder(t, opts)
+	}
+	return typeCoder(t, opts)
+}
@@ -0,0 +1,265 @@
+package codec_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+type testEntry struct {
+	Key   string
+	Value int32 `mc:"varint"`
+}
+
+type testPacket struct {
+	Flag     bool
+	Byte     int8
+	UByte    uint8
+	Short    int16
+	Port     uint16
+	Int      int32
+	Long     int64
+	Float    float32
+	Double   float64
+	Count    int32  `mc:"varint"`
+	Big      int64  `mc:"varlong"`
+	Address  string `mc:"string,max=8"`
+	ID       string `mc:"identifier"`
+	Token    []byte `mc:"bytearray,max=4"`
+	Fixed    [2]byte
+	UUID     types.UUID
+	Sig      *string          `mc:"optional"`
+	Level    int32            `mc:"optional,varint"`
+	Entries  []testEntry      `mc:"array"`
+	Numbers  []int32          `mc:"varint,array,prefix=int"`
+	Reason   text.Component   `mc:"json"`
+	Data     map[string]int32 `mc:"nbt"`
+	Skipped  int              `mc:"-"`
+	internal int
+	Rest     []byte `mc:"rest"`
+}
+
+func testPacketBytes() []byte {
+	var b []byte
+	b = append(b, 0x01)                                           // Flag
+	b = append(b, 0xFF)                                           // Byte
+	b = append(b, 0xFE)                                           // UByte
+	b = append(b, 0x01, 0x00)                                     // Short
+	b = append(b, 0x63, 0xDD)                                     // Port
+	b = append(b, 0x00, 0x00, 0x00, 0x07)                         // Int
+	b = append(b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x08) // Long
+	b = append(b, 0x3F, 0x80, 0x00, 0x00)                         // Float
+	b = append(b, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00) // Double
+	b = append(b, 0xAC, 0x02)                                     // Count
+	b = append(b, 0x01)                                           // Big
+	b = append(b, 0x04, 'h', 'o', 's', 't')                       // Address
+	b = append(b, 0x0F)                                           // ID
+	b = append(b, "minecraft:stone"...)
+	b = append(b, 0x02, 0xAA, 0xBB)                   // Token
+	b = append(b, 0x01, 0x02)                         // Fixed
+	b = append(b, testUUID[:]...)                     // UUID
+	b = append(b, 0x01, 0x03, 's', 'i', 'g')          // Sig
+	b = append(b, 0x00)                               // Level
+	b = append(b, 0x01, 0x01, 'a', 0x05)              // Entries
+	b = append(b, 0x00, 0x00, 0x00, 0x02, 0x01, 0x02) // Numbers
+	b = append(b, 0x0D)                               // Reason
+	b = append(b, `{"text":"Hi"}`...)
+	b = append(b, 0x0A, 0x03, 0x00, 0x01, 'a', 0x00, 0x00, 0x00, 0x01, 0x00) // Data
+	b = append(b, 0xDE, 0xAD)                                                // Rest
+	return b
+}
+
+var testUUID = types.UUID{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5}
+
+func newTestPacket() testPacket {
+	sig := "sig"
+	return testPacket{
+		Flag:    true,
+		Byte:    -1,
+		UByte:   254,
+		Short:   256,
+		Port:    25565,
+		Int:     7,
+		Long:    8,
+		Float:   1,
+		Double:  2,
+		Count:   300,
+		Big:     1,
+		Address: "host",
+		ID:      "minecraft:stone",
+		Token:   []byte{0xAA, 0xBB},
+		Fixed:   [2]byte{1, 2},
+		UUID:    testUUID,
+		Sig:     &sig,
+		Entries: []testEntry{{Key: "a", Value: 5}},
+		Numbers: []int32{1, 2},
+		Reason:  text.Plain("Hi"),
+		Data:    map[string]int32{"a": 1},
+		Rest:    []byte{0xDE, 0xAD},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	p := newTestPacket()
+	p.Skipped = 5
+	p.internal = 6
+	w := &bytes.Buffer{}
+	gotN, err := codec.Write(w, &p)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	want := testPacketBytes()
+	if gotN != int64(len(want)) {
+		t.Errorf("Write() = %v, want %v", gotN, len(want))
+	}
+	if !bytes.Equal(w.Bytes(), want) {
+		t.Errorf("Write() = %v, want %v", w.Bytes(), want)
+	}
+}
+
+func TestRead(t *testing.T) {
+	data := testPacketBytes()
+	var got testPacket
+	gotN, err := codec.Read(bytes.NewReader(data), &got)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if gotN != int64(len(data)) {
+		t.Errorf("Read() = %v, want %v", gotN, len(data))
+	}
+	if want := newTestPacket(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRead_errors(t *testing.T) {
+	type limited struct {
+		Name  string `mc:"string,max=3"`
+		Token []byte `mc:"bytearray,max=1"`
+	}
+	type counted struct {
+		Items []int32 `mc:"array"`
+	}
+	type restArray struct {
+		Items []int16 `mc:"array,prefix=none"`
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		v       any
+		want    any
+		wantErr error
+	}{
+		{
+			name:    "String too long",
+			data:    []byte{0x04, 'a', 'b', 'c', 'd'},
+			v:       new(limited),
+			wantErr: types.ErrStringTooLong,
+		},
+		{
+			name:    "Byte array too long",
+			data:    []byte{0x01, 'a', 0x02, 0x01, 0x02},
+			v:       new(limited),
+			wantErr: codec.ErrTooLong,
+		},
+		{
+			name:    "Negative count",
+			data:    []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F},
+			v:       new(counted),
+			wantErr: codec.ErrInvalidLength,
+		},
+		{
+			name: "Forged count",
+			data: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x07, 0x00, 0x00},
+			v:    new(counted),
+		},
+		{
+			name: "Until end of packet",
+			data: []byte{0x00, 0x01, 0x00, 0x02},
+			v:    new(restArray),
+			want: &restArray{Items: []int16{1, 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codec.Read(bytes.NewReader(tt.data), tt.v)
+			if tt.want != nil {
+				if err != nil {
+					t.Fatalf("Read() error = %v", err)
+				}
+				if !reflect.DeepEqual(tt.v, tt.want) {
+					t.Errorf("Read() = %+v, want %+v", tt.v, tt.want)
+				}
+				return
+			}
+			if err == nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Read() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWrite_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "String too long", v: struct {
+			Name string `mc:"string,max=2"`
+		}{"abc"}},
+		{name: "Slice without array option", v: struct{ Items []int32 }{}},
+		{name: "Unknown kind", v: struct {
+			Count int32 `mc:"varshort"`
+		}{}},
+		{name: "Kind mismatch", v: struct {
+			Count string `mc:"varint"`
+		}{}},
+		{name: "Narrow varint", v: struct {
+			Count int16 `mc:"varint"`
+		}{}},
+		{name: "Wide varint", v: struct {
+			Count int64 `mc:"varint"`
+		}{}},
+		{name: "Narrow varlong", v: struct {
+			Count int32 `mc:"varlong"`
+		}{}},
+		{name: "Unsupported type", v: struct{ C complex64 }{}},
+		{name: "Not a struct", v: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := codec.Write(&bytes.Buffer{}, tt.v); err == nil {
+				t.Errorf("Write() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestRead_jsonLength(t *testing.T) {
+	type disconnect struct {
+		Reason text.Component `mc:"json"`
+	}
+	long := disconnect{Reason: text.Plain(strings.Repeat("a", 40000))}
+	var buf bytes.Buffer
+	if _, err := codec.Write(&buf, &long); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	var got disconnect
+	if _, err := codec.Read(&buf, &got); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, long) {
+		t.Errorf("Read() reason of %d characters, want %d", len(got.Reason.Text), len(long.Reason.Text))
+	}
+
+	tooLong := disconnect{Reason: text.Plain(strings.Repeat("a", text.MaxJSONLength))}
+	if _, err := codec.Write(&bytes.Buffer{}, &tooLong); !errors.Is(err, codec.ErrTooLong) {
+		t.Errorf("Write() error = %v, want %v", err, codec.ErrTooLong)
+	}
+}
@@ -0,0 +1,458 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+	"github.com/nonya123456/cobble/proto/stream"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+var (
+	readerFromType = reflect.TypeFor[io.ReaderFrom]()
+	writerToType   = reflect.TypeFor[io.WriterTo]()
+)
+
+// typeCoder infers the protocol type of t.
+func typeCoder(t reflect.Type, opts options) (coder, error) {
+	if pt := reflect.PointerTo(t); pt.Implements(readerFromType) && pt.Implements(writerToType) {
+		return coder{
+			read: func(r io.Reader, v reflect.Value) (int64, error) {
+				return v.Addr().Interface().(io.ReaderFrom).ReadFrom(r)
+			},
+			write: func(w io.Writer, v reflect.Value) (int64, error) {
+				return v.Addr().Interface().(io.WriterTo).WriteTo(w)
+			},
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return boolCoder(), nil
+	case reflect.Int8:
+		return intCoder[types.Byte](), nil
+	case reflect.Uint8:
+		return uintCoder[types.UnsignedByte](), nil
+	case reflect.Int16:
+		return intCoder[types.Short](), nil
+	case reflect.Uint16:
+		return uintCoder[types.UnsignedShort](), nil
+	case reflect.Int32:
+		return intCoder[types.Int](), nil
+	case reflect.Int64:
+		return intCoder[types.Long](), nil
+	case reflect.Float32:
+		return floatCoder[types.Float](), nil
+	case reflect.Float64:
+		return floatCoder[types.Double](), nil
+	case reflect.String:
+		return stringCoder(opts.max), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return byteArrayCoder(opts.max), nil
+		}
+		return coder{}, fmt.Errorf("slice of %s requires the array option", t.Elem())
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fixedBytesCoder(), nil
+		}
+	case reflect.Struct:
+		return structCoder(t)
+	}
+	return coder{}, fmt.Errorf("unsupported type %s", t)
+}
+
+func kindCoder(t reflect.Type, opts options) (coder, error) {
+	switch opts.kind {
+	// Narrower fields would truncate decoded values and wider ones those
+	// written, so each kind takes only its own width.
+	case "varint":
+		if t.Kind() == reflect.Int32 {
+			return intCoder[types.VarInt](), nil
+		}
+	case "varlong":
+		if t.Kind() == reflect.Int64 {
+			return intCoder[types.VarLong](), nil
+		}
+	case "string":
+		if t.Kind() == reflect.String {
+			return stringCoder(opts.max), nil
+		}
+	case "identifier":
+		if t.Kind() == reflect.String {
+			return identifierCoder(), nil
+		}
+	case "bytearray":
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return byteArrayCoder(opts.max), nil
+		}
+	case "rest":
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return restCoder(), nil
+		}
+	case "json":
+		return jsonCoder(opts.max), nil
+	case "nbt":
+		return nbtCoder(), nil
+	default:
+		return coder{}, fmt.Errorf("unknown kind %q", opts.kind)
+	}
+	return coder{}, fmt.Errorf("kind %q cannot encode %s", opts.kind, t)
+}
+
+// primitive is a types value of underlying type T.
+type primitive[T any] interface {
+	*T
+	io.ReaderFrom
+	io.WriterTo
+}
+
+func boolCoder() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var b types.Boolean
+			n, err := b.ReadFrom(r)
+			v.SetBool(bool(b))
+			return n, err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			b := types.Boolean(v.Bool())
+			return b.WriteTo(w)
+		},
+	}
+}
+
+func intCoder[T ~int8 | ~int16 | ~int32 | ~int64, P primitive[T]]() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var x T
+			n, err := P(&x).ReadFrom(r)
+			v.SetInt(int64(x))
+			return n, err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			x := T(v.Int())
+			return P(&x).WriteTo(w)
+		},
+	}
+}
+
+func uintCoder[T ~uint8 | ~uint16, P primitive[T]]() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var x T
+			n, err := P(&x).ReadFrom(r)
+			v.SetUint(uint64(x))
+			return n, err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			x := T(v.Uint())
+			return P(&x).WriteTo(w)
+		},
+	}
+}
+
+func floatCoder[T ~float32 | ~float64, P primitive[T]]() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var x T
+			n, err := P(&x).ReadFrom(r)
+			v.SetFloat(float64(x))
+			return n, err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			x := T(v.Float())
+			return P(&x).WriteTo(w)
+		},
+	}
+}
+
+// stringCoder limits strings to maxLength UTF-16 code units when it is
+// set.
+func stringCoder(maxLength int) coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var s types.String
+			var n int64
+			var err error
+			if maxLength > 0 {
+				n, err = types.LimitString(&s, maxLength).ReadFrom(r)
+			} else {
+				n, err = s.ReadFrom(r)
+			}
+			if err != nil {
+				return n, err
+			}
+			v.SetString(string(s))
+			return n, nil
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			s := types.String(v.String())
+			// Code units never outnumber bytes, so the exact count is only
+			// needed for long strings.
+			if maxLength > 0 && len(s) > maxLength && types.UTF16Len(string(s)) > maxLength {
+				return 0, ErrTooLong
+			}
+			return s.WriteTo(w)
+		},
+	}
+}
+
+func identifierCoder() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var id types.Identifier
+			n, err := id.ReadFrom(r)
+			if err != nil {
+				return n, err
+			}
+			v.SetString(string(id))
+			return n, nil
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			id := types.Identifier(v.String())
+			return id.WriteTo(w)
+		},
+	}
+}
+
+func byteArrayCoder(maxLength int) coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var length types.VarInt
+			n, err := length.ReadFrom(r)
+			if err != nil {
+				return n, err
+			}
+			if length < 0 {
+				return n, ErrInvalidLength
+			}
+			if maxLength > 0 && int(length) > maxLength {
+				return n, ErrTooLong
+			}
+			b, err := stream.ReadBytes(r, int(length))
+			n += int64(len(b))
+			if err != nil {
+				return n, err
+			}
+			v.SetBytes(b)
+			return n, nil
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			if maxLength > 0 && v.Len() > maxLength {
+				return 0, ErrTooLong
+			}
+			b := types.ByteArray(v.Bytes())
+			return b.WriteTo(w)
+		},
+	}
+}
+
+func fixedBytesCoder() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			n, err := io.ReadFull(r, v.Bytes())
+			return int64(n), err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			n, err := w.Write(v.Bytes())
+			return int64(n), err
+		},
+	}
+}
+
+func restCoder() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			b, err := io.ReadAll(r)
+			v.SetBytes(b)
+			return int64(len(b)), err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			n, err := w.Write(v.Bytes())
+			return int64(n), err
+		},
+	}
+}
+
+func jsonCoder(maxLength int) coder {
+	if maxLength == 0 {
+		maxLength = text.MaxJSONLength
+	}
+	str := stringCoder(maxLength)
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var s string
+			n, err := str.read(r, reflect.ValueOf(&s).Elem())
+			if err != nil {
+				return n, err
+			}
+			return n, json.Unmarshal([]byte(s), v.Addr().Interface())
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			data, err := json.Marshal(v.Interface())
+			if err != nil {
+				return 0, err
+			}
+			return str.write(w, reflect.ValueOf(string(data)))
+		},
+	}
+}
+
+func nbtCoder() coder {
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			d := nbt.NewDecoder(r)
+			err := d.Decode(v.Addr().Interface())
+			return d.InputOffset(), err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			data, err := nbt.Marshal(v.Interface())
+			if err != nil {
+				return 0, err
+			}
+			n, err := w.Write(data)
+			return int64(n), err
+		},
+	}
+}
+
+// optionalCoder prefixes the value with a Boolean. A nil pointer or zero
+// value is sent as absent.
+func optionalCoder(t reflect.Type, opts options) (coder, error) {
+	isPointer := t.Kind() == reflect.Pointer
+	elemType := t
+	if isPointer {
+		elemType = t.Elem()
+	}
+	inner, err := newCoder(elemType, opts)
+	if err != nil {
+		return coder{}, err
+	}
+
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var present types.Boolean
+			n, err := present.ReadFrom(r)
+			if err != nil {
+				return n, err
+			}
+			if !present {
+				v.SetZero()
+				return n, nil
+			}
+			if isPointer {
+				if v.IsNil() {
+					v.Set(reflect.New(elemType))
+				}
+				v = v.Elem()
+			}
+			n1, err := inner.read(r, v)
+			return n + n1, err
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			present := types.Boolean(!v.IsZero())
+			n, err := present.WriteTo(w)
+			if err != nil || !present {
+				return n, err
+			}
+			if isPointer {
+				v = v.Elem()
+			}
+			n1, err := inner.write(w, v)
+			return n + n1, err
+		},
+	}, nil
+}
+
+// arrayCoder encodes a slice with a count prefix, or until the end of the
+// packet when the prefix is "none".
+func arrayCoder(t reflect.Type, opts options) (coder, error) {
+	if t.Kind() != reflect.Slice {
+		return coder{}, fmt.Errorf("array option on non-slice %s", t)
+	}
+	prefix := opts.prefix
+	opts.prefix = ""
+	elem, err := newCoder(t.Elem(), opts)
+	if err != nil {
+		return coder{}, err
+	}
+	count, err := countCoder(prefix)
+	if err != nil {
+		return coder{}, err
+	}
+
+	return coder{
+		read: func(r io.Reader, v reflect.Value) (int64, error) {
+			var n int64
+			length := -1
+			if count.read != nil {
+				var c int
+				n1, err := count.read(r, reflect.ValueOf(&c).Elem())
+				n += n1
+				if err != nil {
+					return n, err
+				}
+				if c < 0 {
+					return n, ErrInvalidLength
+				}
+				length = c
+			}
+
+			// Grow as elements arrive, for the same reason as
+			// stream.ReadBytes.
+			s := reflect.MakeSlice(t, 0, min(max(length, 0), 1024))
+			for i := 0; length < 0 || i < length; i++ {
+				s = reflect.Append(s, reflect.Zero(t.Elem()))
+				n1, err := elem.read(r, s.Index(i))
+				n += n1
+				if length < 0 && n1 == 0 && errors.Is(err, io.EOF) {
+					s = s.Slice(0, i)
+					break
+				}
+				if err != nil {
+					return n, err
+				}
+			}
+			if s.Len() > 0 {
+				v.Set(s)
+			}
+			return n, nil
+		},
+		write: func(w io.Writer, v reflect.Value) (int64, error) {
+			var n int64
+			if count.write != nil {
+				n1, err := count.write(w, reflect.ValueOf(v.Len()))
+				n += n1
+				if err != nil {
+					return n, err
+				}
+			}
+			for i := range v.Len() {
+				n1, err := elem.write(w, v.Index(i))
+				n += n1
+				if err != nil {
+					return n, err
+				}
+			}
+			return n, nil
+		},
+	}, nil
+}
+
+func countCoder(prefix string) (coder, error) {
+	switch prefix {
+	case "", "varint":
+		return intCoder[types.VarInt](), nil
+	case "int":
+		return intCoder[types.Int](), nil
+	case "none":
+		return coder{}, nil
+	}
+	return coder{}, fmt.Errorf("unknown prefix %q", prefix)
+}
@@ -0,0 +1,121 @@
+package proto
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+
+	"github.com/nonya123456/cobble/proto/stream"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const MaxUncompressedLength = 1 << 23
+
+var (
+	ErrInvalidDataLength = errors.New("invalid data length")
+)
+
+func ReadCompressedPacket(r io.Reader, threshold int) (Packet, error) {
+	return ReadCompressedPacketLimit(r, threshold, DefaultMaxPacketLength)
+}
+
+// ReadCompressedPacketLimit is like ReadCompressedPacket but rejects frames
+// longer than maxLength bytes.
+func ReadCompressedPacketLimit(r io.Reader, threshold, maxLength int) (Packet, error) {
+	length, err := readPacketLength(r, maxLength)
+	if err != nil {
+		return Packet{}, err
+	}
+
+	var dataLengthProto types.VarInt
+	dataLengthLength, err := dataLengthProto.ReadFrom(r)
+	if err != nil {
+		return Packet{}, err
+	}
+	dataLength := int(dataLengthProto)
+
+	bodyLength := length - int(dataLengthLength)
+	if bodyLength < 0 {
+		return Packet{}, ErrInvalidPacketLength
+	}
+	body, err := stream.ReadBytes(r, bodyLength)
+	if err != nil {
+		return Packet{}, err
+	}
+
+	if dataLength == 0 {
+		return readPacketBody(body)
+	}
+	if dataLength < threshold || dataLength > MaxUncompressedLength {
+		return Packet{}, ErrInvalidDataLength
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return Packet{}, err
+	}
+	defer zr.Close()
+	data, err := stream.ReadBytes(zr, dataLength)
+	if err != nil {
+		return Packet{}, err
+	}
+	if n, _ := zr.Read(make([]byte, 1)); n != 0 {
+		return Packet{}, ErrInvalidDataLength
+	}
+	return readPacketBody(data)
+}
+
+func readPacketBody(body []byte) (Packet, error) {
+	r := bytes.NewReader(body)
+	var id types.VarInt
+	if _, err := id.ReadFrom(r); err != nil {
+		return Packet{}, err
+	}
+	return Packet{
+		ID:   int32(id),
+		Data: body[len(body)-r.Len():],
+	}, nil
+}
+
+func WriteCompressedPacket(w io.Writer, id int32, p io.WriterTo, threshold int) error {
+	buf := bytes.Buffer{}
+	idProto := types.VarInt(id)
+	if _, err := idProto.WriteTo(&buf); err != nil {
+		return err
+	}
+	if _, err := p.WriteTo(&buf); err != nil {
+		return err
+	}
+
+	frame := bytes.Buffer{}
+	body := buf.Bytes()
+	if len(body) < threshold {
+		dataLength := types.VarInt(0)
+		if _, err := dataLength.WriteTo(&frame); err != nil {
+			return err
+		}
+		frame.Write(body)
+	} else {
+		dataLength := types.VarInt(len(body))
+		if _, err := dataLength.WriteTo(&frame); err != nil {
+			return err
+		}
+		zw := zlib.NewWriter(&frame)
+		if _, err := zw.Write(body); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+
+	length := types.VarInt(frame.Len())
+	if _, err := length.WriteTo(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(frame.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package proto_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+)
+
+func compress(b []byte) []byte {
+	buf := bytes.Buffer{}
+	zw := zlib.NewWriter(&buf)
+	zw.Write(b)
+	zw.Close()
+	return buf.Bytes()
+}
+
+func compressedFrame(dataLength byte, body []byte) []byte {
+	return append([]byte{byte(len(body) + 1), dataLength}, body...)
+}
+
+func TestReadCompressedPacket(t *testing.T) {
+	type args struct {
+		r         io.Reader
+		threshold int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    proto.Packet
+		wantErr bool
+	}{
+		{
+			name:    "Below threshold",
+			args:    args{bytes.NewReader(compressedFrame(0x00, []byte{0x01, 'a', 'b', 'c'})), 5},
+			want:    proto.Packet{ID: 1, Data: []byte("abc")},
+			wantErr: false,
+		},
+		{
+			name:    "Above threshold",
+			args:    args{bytes.NewReader(compressedFrame(0x06, compress([]byte{0x01, 'a', 'b', 'c', 'd', 'e'}))), 5},
+			want:    proto.Packet{ID: 1, Data: []byte("abcde")},
+			wantErr: false,
+		},
+		{
+			name:    "Compressed below threshold",
+			args:    args{bytes.NewReader(compressedFrame(0x04, compress([]byte{0x01, 'a', 'b', 'c'}))), 5},
+			wantErr: true,
+		},
+		{
+			name:    "Data length mismatch",
+			args:    args{bytes.NewReader(compressedFrame(0x05, compress([]byte{0x01, 'a', 'b', 'c', 'd', 'e'}))), 5},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid zlib stream",
+			args:    args{bytes.NewReader(compressedFrame(0x06, []byte{0x01, 'a', 'b', 'c', 'd', 'e'})), 5},
+			wantErr: true,
+		},
+		{
+			name:    "Truncated frame",
+			args:    args{bytes.NewReader([]byte{0x05, 0x00, 0x01}), 5},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := proto.ReadCompressedPacket(tt.args.r, tt.args.threshold)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadCompressedPacket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadCompressedPacket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCompressedPacket(t *testing.T) {
+	type args struct {
+		id        int32
+		p         io.WriterTo
+		threshold int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:    "Just below threshold",
+			args:    args{id: 1, p: bytes.NewBufferString("abc"), threshold: 5},
+			wantW:   compressedFrame(0x00, []byte{0x01, 'a', 'b', 'c'}),
+			wantErr: false,
+		},
+		{
+			name:    "At threshold",
+			args:    args{id: 1, p: bytes.NewBufferString("abcd"), threshold: 5},
+			wantW:   compressedFrame(0x05, compress([]byte{0x01, 'a', 'b', 'c', 'd'})),
+			wantErr: false,
+		},
+		{
+			name:    "Just above threshold",
+			args:    args{id: 1, p: bytes.NewBufferString("abcde"), threshold: 5},
+			wantW:   compressedFrame(0x06, compress([]byte{0x01, 'a', 'b', 'c', 'd', 'e'})),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			if err := proto.WriteCompressedPacket(w, tt.args.id, tt.args.p, tt.args.threshold); (err != nil) != tt.wantErr {
+				t.Errorf("WriteCompressedPacket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("WriteCompressedPacket() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestCompressedPacket_roundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("chunk"), 1000)
+	w := &bytes.Buffer{}
+	if err := proto.WriteCompressedPacket(w, 0x28, bytes.NewBuffer(data), 256); err != nil {
+		t.Fatalf("WriteCompressedPacket() error = %v", err)
+	}
+	if w.Len() >= len(data) {
+		t.Errorf("WriteCompressedPacket() wrote %d bytes, want fewer than %d", w.Len(), len(data))
+	}
+	got, err := proto.ReadCompressedPacket(w, 256)
+	if err != nil {
+		t.Fatalf("ReadCompressedPacket() error = %v", err)
+	}
+	if want := (proto.Packet{ID: 0x28, Data: data}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCompressedPacket() = %v bytes of %#x, want %v bytes of %#x", len(got.Data), got.ID, len(want.Data), want.ID)
+	}
+}
@@ -0,0 +1,24 @@
+package configuration
+
+import "github.com/nonya123456/cobble/proto/types"
+
+const (
+	ChatModeEnabled int32 = iota
+	ChatModeCommandsOnly
+	ChatModeHidden
+)
+
+const (
+	MainHandLeft int32 = iota
+	MainHandRight
+)
+
+const (
+	ParticleStatusAll int32 = iota
+	ParticleStatusDecreased
+	ParticleStatusMinimal
+)
+
+// BrandChannel is the plugin channel on which client and server announce
+// their brand as a String.
+const BrandChannel types.Identifier = "minecraft:brand"
@@ -0,0 +1,224 @@
+// Code generated by packetgen from packets.json. DO NOT EDIT.
+
+package configuration
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const (
+	ClientInformationID              = 0x00
+	ServerboundPluginMessageID       = 0x02
+	AcknowledgeFinishConfigurationID = 0x03
+	ServerboundKeepAliveID           = 0x04
+	ServerboundKnownPacksID          = 0x07
+	ClientboundPluginMessageID       = 0x01
+	DisconnectID                     = 0x02
+	FinishConfigurationID            = 0x03
+	ClientboundKeepAliveID           = 0x04
+	RegistryDataID                   = 0x07
+	FeatureFlagsID                   = 0x0C
+	UpdateTagsID                     = 0x0D
+	ClientboundKnownPacksID          = 0x0E
+)
+
+type RegistryEntry struct {
+	ID   types.Identifier
+	Data any `mc:"optional,nbt"`
+}
+
+type Tag struct {
+	Name    types.Identifier
+	Entries []int32 `mc:"array,varint"`
+}
+
+type RegistryTags struct {
+	Registry types.Identifier
+	Tags     []Tag `mc:"array"`
+}
+
+type KnownPack struct {
+	Namespace string
+	ID        string
+	Version   string
+}
+
+// ClientInformation is the serverbound configuration packet 0x00.
+type ClientInformation struct {
+	Locale              string `mc:"string,max=16"`
+	ViewDistance        int8
+	ChatMode            int32 `mc:"varint"`
+	ChatColors          bool
+	DisplayedSkinParts  uint8
+	MainHand            int32 `mc:"varint"`
+	EnableTextFiltering bool
+	AllowServerListings bool
+	ParticleStatus      int32 `mc:"varint"`
+}
+
+func (c *ClientInformation) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ClientInformation) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
+
+// ServerboundPluginMessage is the serverbound configuration packet 0x02.
+type ServerboundPluginMessage struct {
+	Channel types.Identifier
+	Data    []byte `mc:"rest"`
+}
+
+func (s *ServerboundPluginMessage) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *ServerboundPluginMessage) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
+
+// AcknowledgeFinishConfiguration is the serverbound configuration packet 0x03.
+type AcknowledgeFinishConfiguration struct{}
+
+func (a *AcknowledgeFinishConfiguration) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, a)
+}
+
+func (a *AcknowledgeFinishConfiguration) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, a)
+}
+
+// ServerboundKeepAlive is the serverbound configuration packet 0x04.
+type ServerboundKeepAlive struct {
+	ID int64
+}
+
+func (s *ServerboundKeepAlive) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *ServerboundKeepAlive) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
+
+// ServerboundKnownPacks is the serverbound configuration packet 0x07.
+type ServerboundKnownPacks struct {
+	Packs []KnownPack `mc:"array"`
+}
+
+func (s *ServerboundKnownPacks) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *ServerboundKnownPacks) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
+
+// ClientboundPluginMessage is the clientbound configuration packet 0x01.
+type ClientboundPluginMessage struct {
+	Channel types.Identifier
+	Data    []byte `mc:"rest"`
+}
+
+func (c *ClientboundPluginMessage) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ClientboundPluginMessage) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
+
+// Disconnect is the clientbound configuration packet 0x02.
+type Disconnect struct {
+	Reason text.Component
+}
+
+func (d *Disconnect) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, d)
+}
+
+func (d *Disconnect) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, d)
+}
+
+// FinishConfiguration is the clientbound configuration packet 0x03.
+type FinishConfiguration struct{}
+
+func (f *FinishConfiguration) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, f)
+}
+
+func (f *FinishConfiguration) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, f)
+}
+
+// ClientboundKeepAlive is the clientbound configuration packet 0x04.
+type ClientboundKeepAlive struct {
+	ID int64
+}
+
+func (c *ClientboundKeepAlive) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ClientboundKeepAlive) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
+
+// RegistryData is the clientbound configuration packet 0x07.
+type RegistryData struct {
+	Registry types.Identifier
+	Entries  []RegistryEntry `mc:"array"`
+}
+
+func (rd *RegistryData) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, rd)
+}
+
+func (rd *RegistryData) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, rd)
+}
+
+// FeatureFlags is the clientbound configuration packet 0x0C.
+type FeatureFlags struct {
+	Flags []types.Identifier `mc:"array"`
+}
+
+func (f *FeatureFlags) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, f)
+}
+
+func (f *FeatureFlags) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, f)
+}
+
+// UpdateTags is the clientbound configuration packet 0x0D.
+type UpdateTags struct {
+	Registries []RegistryTags `mc:"array"`
+}
+
+func (u *UpdateTags) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, u)
+}
+
+func (u *UpdateTags) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, u)
+}
+
+// ClientboundKnownPacks is the clientbound configuration packet 0x0E.
+type ClientboundKnownPacks struct {
+	Packs []KnownPack `mc:"array"`
+}
+
+func (c *ClientboundKnownPacks) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ClientboundKnownPacks) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
@@ -0,0 +1,181 @@
+package proto
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nonya123456/cobble/proto/crypt"
+)
+
+// Conn frames packets over a network connection, applying compression
+// and encryption once they have been enabled. WritePacket may be called
+// from several goroutines, but reading and switching modes must happen on
+// the goroutine that reads packets.
+type Conn struct {
+	conn  net.Conn
+	state atomic.Int32
+
+	br        *bufio.Reader
+	r         io.Reader
+	maxLength int
+
+	writeMu      sync.Mutex
+	bw           *bufio.Writer
+	w            io.Writer
+	threshold    atomic.Int64
+	writeTimeout atomic.Int64
+}
+
+func NewConn(conn net.Conn) *Conn {
+	c := &Conn{
+		conn: conn,
+		br:   bufio.NewReader(conn),
+		bw:   bufio.NewWriter(conn),
+
+		maxLength: DefaultMaxPacketLength,
+	}
+	c.r = c.br
+	c.w = c.bw
+	c.threshold.Store(-1)
+	return c
+}
+
+func (c *Conn) State() State {
+	return State(c.state.Load())
+}
+
+func (c *Conn) SetState(s State) {
+	c.state.Store(int32(s))
+}
+
+// SetMaxPacketLength sets the largest frame length ReadPacket accepts.
+func (c *Conn) SetMaxPacketLength(n int) {
+	c.maxLength = n
+}
+
+// SetWriteTimeout bounds each packet write, so that a peer that stops
+// reading cannot block the writer forever. Zero disables the timeout.
+func (c *Conn) SetWriteTimeout(d time.Duration) {
+	c.writeTimeout.Store(int64(d))
+}
+
+// SetReadDeadline sets the deadline for reads on the underlying
+// connection, as net.Conn.SetReadDeadline.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// setWriteDeadline applies the write timeout. It is called with writeMu
+// held.
+func (c *Conn) setWriteDeadline() error {
+	d := time.Duration(c.writeTimeout.Load())
+	if d <= 0 {
+		return nil
+	}
+	return c.conn.SetWriteDeadline(time.Now().Add(d))
+}
+
+func (c *Conn) CompressionThreshold() int {
+	return int(c.threshold.Load())
+}
+
+// SetCompressionThreshold switches both directions to the compressed
+// framing. A negative threshold switches back to uncompressed framing.
+func (c *Conn) SetCompressionThreshold(threshold int) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.threshold.Store(int64(threshold))
+}
+
+func (c *Conn) EnableEncryption(sharedSecret []byte) error {
+	encrypter, decrypter, err := crypt.NewStreams(sharedSecret)
+	if err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.r = cipher.StreamReader{S: decrypter, R: c.br}
+	c.w = cipher.StreamWriter{S: encrypter, W: c.bw}
+	return nil
+}
+
+func (c *Conn) ReadPacket() (Packet, error) {
+	var p Packet
+	var err error
+	if threshold := c.CompressionThreshold(); threshold >= 0 {
+		p, err = ReadCompressedPacketLimit(c.r, threshold, c.maxLength)
+	} else {
+		p, err = ReadPacketLimit(c.r, c.maxLength)
+	}
+
+	var tooLarge *PacketTooLargeError
+	if errors.As(err, &tooLarge) {
+		tooLarge.Addr = c.RemoteAddr()
+	}
+	return p, err
+}
+
+func (c *Conn) WritePacket(id int32, p io.WriterTo) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.setWriteDeadline(); err != nil {
+		return err
+	}
+
+	var err error
+	if threshold := c.CompressionThreshold(); threshold >= 0 {
+		err = WriteCompressedPacket(c.w, id, p, threshold)
+	} else {
+		err = WritePacket(c.w, id, p)
+	}
+	if err != nil {
+		return err
+	}
+	return c.bw.Flush()
+}
+
+// Peek returns the next n bytes without consuming them, as read from the
+// network before any decryption.
+func (c *Conn) Peek(n int) ([]byte, error) {
+	return c.br.Peek(n)
+}
+
+// Buffered returns the number of bytes that can be read without blocking.
+func (c *Conn) Buffered() int {
+	return c.br.Buffered()
+}
+
+// Read reads raw bytes from the connection, bypassing packet framing.
+func (c *Conn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+// Write writes raw bytes to the connection, bypassing packet framing.
+func (c *Conn) Write(p []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.setWriteDeadline(); err != nil {
+		return 0, err
+	}
+
+	n, err := c.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, c.bw.Flush()
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,135 @@
+package proto_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nonya123456/cobble/proto"
+)
+
+func newTestConns(t *testing.T) (*proto.Conn, *proto.Conn) {
+	t.Helper()
+	a, b := net.Pipe()
+	t.Cleanup(func() {
+		a.Close()
+		b.Close()
+	})
+	return proto.NewConn(a), proto.NewConn(b)
+}
+
+func exchange(t *testing.T, from, to *proto.Conn, id int32, data []byte) {
+	t.Helper()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- from.WritePacket(id, bytes.NewBuffer(data))
+	}()
+	got, err := to.ReadPacket()
+	if err != nil {
+		t.Fatalf("Conn.ReadPacket() error = %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("Conn.WritePacket() error = %v", err)
+	}
+	if want := (proto.Packet{ID: id, Data: data}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Conn.ReadPacket() = %v, want %v", got, want)
+	}
+}
+
+func TestConn_modes(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	large := bytes.Repeat([]byte("x"), 300)
+	tests := []struct {
+		name       string
+		threshold  int
+		encryption bool
+	}{
+		{name: "Plain", threshold: -1},
+		{name: "Compressed", threshold: 256},
+		{name: "Encrypted", threshold: -1, encryption: true},
+		{name: "Compressed and encrypted", threshold: 256, encryption: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestConns(t)
+			exchange(t, server, client, 0x00, []byte("before"))
+			if tt.encryption {
+				if err := server.EnableEncryption(secret); err != nil {
+					t.Fatalf("Conn.EnableEncryption() error = %v", err)
+				}
+				if err := client.EnableEncryption(secret); err != nil {
+					t.Fatalf("Conn.EnableEncryption() error = %v", err)
+				}
+			}
+			server.SetCompressionThreshold(tt.threshold)
+			client.SetCompressionThreshold(tt.threshold)
+
+			exchange(t, server, client, 0x01, []byte("small"))
+			exchange(t, client, server, 0x02, large)
+			exchange(t, server, client, 0x03, large)
+		})
+	}
+}
+
+func TestConn_State(t *testing.T) {
+	c, _ := newTestConns(t)
+	if got := c.State(); got != proto.StateHandshaking {
+		t.Errorf("Conn.State() = %v, want %v", got, proto.StateHandshaking)
+	}
+	c.SetState(proto.StatePlay)
+	if got := c.State(); got != proto.StatePlay {
+		t.Errorf("Conn.State() = %v, want %v", got, proto.StatePlay)
+	}
+}
+
+func TestConn_Peek(t *testing.T) {
+	server, client := newTestConns(t)
+	go client.Write([]byte{0xFE, 0x01})
+
+	got, err := server.Peek(1)
+	if err != nil {
+		t.Fatalf("Conn.Peek() error = %v", err)
+	}
+	if !bytes.Equal(got, []byte{0xFE}) {
+		t.Errorf("Conn.Peek() = %v, want [254]", got)
+	}
+	if n := server.Buffered(); n != 2 {
+		t.Errorf("Conn.Buffered() = %v, want 2", n)
+	}
+	all := make([]byte, 2)
+	if _, err := server.Read(all); err != nil {
+		t.Fatalf("Conn.Read() error = %v", err)
+	}
+	if !bytes.Equal(all, []byte{0xFE, 0x01}) {
+		t.Errorf("Conn.Read() = %v, want [254 1]", all)
+	}
+}
+
+func TestConn_SetMaxPacketLength(t *testing.T) {
+	server, client := newTestConns(t)
+	server.SetMaxPacketLength(4)
+	go client.WritePacket(0x01, bytes.NewBufferString("Hello"))
+
+	_, err := server.ReadPacket()
+	var tooLarge *proto.PacketTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Conn.ReadPacket() error = %v, want PacketTooLargeError", err)
+	}
+	if tooLarge.Length != 6 || tooLarge.Addr != server.RemoteAddr() {
+		t.Errorf("PacketTooLargeError = %+v, want length 6 from %v", tooLarge, server.RemoteAddr())
+	}
+}
+
+func TestConn_SetWriteTimeout(t *testing.T) {
+	server, _ := newTestConns(t)
+	server.SetWriteTimeout(10 * time.Millisecond)
+
+	err := server.WritePacket(0x01, bytes.NewBufferString("Hello"))
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Conn.WritePacket() error = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
@@ -0,0 +1,52 @@
+package crypt
+
+import "crypto/cipher"
+
+// cfb8 implements 8-bit cipher feedback mode, which the Minecraft protocol
+// uses for stream encryption but crypto/cipher does not provide.
+type cfb8 struct {
+	block   cipher.Block
+	shift   []byte
+	out     []byte
+	decrypt bool
+}
+
+func NewCFB8Encrypter(block cipher.Block, iv []byte) cipher.Stream {
+	return newCFB8(block, iv, false)
+}
+
+func NewCFB8Decrypter(block cipher.Block, iv []byte) cipher.Stream {
+	return newCFB8(block, iv, true)
+}
+
+func newCFB8(block cipher.Block, iv []byte, decrypt bool) cipher.Stream {
+	blockSize := block.BlockSize()
+	if len(iv) != blockSize {
+		panic("crypt: IV length must equal block size")
+	}
+	c := &cfb8{
+		block:   block,
+		shift:   make([]byte, blockSize),
+		out:     make([]byte, blockSize),
+		decrypt: decrypt,
+	}
+	copy(c.shift, iv)
+	return c
+}
+
+func (c *cfb8) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("crypt: output smaller than input")
+	}
+	for i, b := range src {
+		c.block.Encrypt(c.out, c.shift)
+		x := b ^ c.out[0]
+		copy(c.shift, c.shift[1:])
+		if c.decrypt {
+			c.shift[len(c.shift)-1] = b
+		} else {
+			c.shift[len(c.shift)-1] = x
+		}
+		dst[i] = x
+	}
+}
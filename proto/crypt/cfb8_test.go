@@ -0,0 +1,90 @@
+package crypt_test
+
+import (
+	"crypto/aes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/crypt"
+)
+
+// Test vectors from NIST SP 800-38A, F.3.7 and F.3.8.
+var (
+	cfb8Key        = mustDecodeHex("2b7e151628aed2a6abf7158809cf4f3c")
+	cfb8IV         = mustDecodeHex("000102030405060708090a0b0c0d0e0f")
+	cfb8Plaintext  = mustDecodeHex("6bc1bee22e409f96e93d7e117393172aae2d")
+	cfb8Ciphertext = mustDecodeHex("3b79424c9c0dd436bace9e0ed4586a4f32b9")
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestCFB8Encrypter(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []int
+	}{
+		{name: "Single call", chunks: []int{len(cfb8Plaintext)}},
+		{name: "Byte at a time", chunks: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{name: "Uneven chunks", chunks: []int{5, 11, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, _ := aes.NewCipher(cfb8Key)
+			s := crypt.NewCFB8Encrypter(block, cfb8IV)
+			got := make([]byte, 0, len(cfb8Plaintext))
+			offset := 0
+			for _, n := range tt.chunks {
+				dst := make([]byte, n)
+				s.XORKeyStream(dst, cfb8Plaintext[offset:offset+n])
+				got = append(got, dst...)
+				offset += n
+			}
+			if !reflect.DeepEqual(got, cfb8Ciphertext) {
+				t.Errorf("XORKeyStream() = %x, want %x", got, cfb8Ciphertext)
+			}
+		})
+	}
+}
+
+func TestCFB8Decrypter(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []int
+	}{
+		{name: "Single call", chunks: []int{len(cfb8Ciphertext)}},
+		{name: "Uneven chunks", chunks: []int{7, 1, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, _ := aes.NewCipher(cfb8Key)
+			s := crypt.NewCFB8Decrypter(block, cfb8IV)
+			got := make([]byte, 0, len(cfb8Ciphertext))
+			offset := 0
+			for _, n := range tt.chunks {
+				dst := make([]byte, n)
+				s.XORKeyStream(dst, cfb8Ciphertext[offset:offset+n])
+				got = append(got, dst...)
+				offset += n
+			}
+			if !reflect.DeepEqual(got, cfb8Plaintext) {
+				t.Errorf("XORKeyStream() = %x, want %x", got, cfb8Plaintext)
+			}
+		})
+	}
+}
+
+func TestCFB8_inPlace(t *testing.T) {
+	block, _ := aes.NewCipher(cfb8Key)
+	buf := append([]byte(nil), cfb8Ciphertext...)
+	crypt.NewCFB8Decrypter(block, cfb8IV).XORKeyStream(buf, buf)
+	if !reflect.DeepEqual(buf, cfb8Plaintext) {
+		t.Errorf("XORKeyStream() = %x, want %x", buf, cfb8Plaintext)
+	}
+}
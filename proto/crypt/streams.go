@@ -0,0 +1,16 @@
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+)
+
+// NewStreams returns the encrypting and decrypting streams for a shared
+// secret, which the protocol uses as both the AES key and the IV.
+func NewStreams(sharedSecret []byte) (cipher.Stream, cipher.Stream, error) {
+	block, err := aes.NewCipher(sharedSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewCFB8Encrypter(block, sharedSecret), NewCFB8Decrypter(block, sharedSecret), nil
+}
@@ -0,0 +1,39 @@
+package crypt_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/crypt"
+)
+
+func TestNewStreams(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	encrypter, _, err := crypt.NewStreams(secret)
+	if err != nil {
+		t.Fatalf("NewStreams() error = %v", err)
+	}
+	_, decrypter, err := crypt.NewStreams(secret)
+	if err != nil {
+		t.Fatalf("NewStreams() error = %v", err)
+	}
+
+	// Each side keeps its stream state across writes, as on a connection.
+	for _, msg := range []string{"secret", "hello"} {
+		buf := []byte(msg)
+		encrypter.XORKeyStream(buf, buf)
+		if bytes.Equal(buf, []byte(msg)) {
+			t.Errorf("encrypter left %q unchanged", msg)
+		}
+		decrypter.XORKeyStream(buf, buf)
+		if string(buf) != msg {
+			t.Errorf("decrypter = %q, want %q", buf, msg)
+		}
+	}
+}
+
+func TestNewStreams_invalidSecret(t *testing.T) {
+	if _, _, err := crypt.NewStreams([]byte("short")); err == nil {
+		t.Errorf("NewStreams() error = nil, want error")
+	}
+}
@@ -3,41 +3,24 @@ package handshaking
 import (
 	"io"
 
-	"github.com/nonya123456/cobble/proto/stream"
-	"github.com/nonya123456/cobble/proto/types"
+	"github.com/nonya123456/cobble/proto/codec"
 )
 
 const HandshakeID int32 = 0x00
 
+const MaxServerAddressLength = 255
+
 type Handshake struct {
-	ProtocolVersion int32
-	ServerAddress   string
+	ProtocolVersion int32  `mc:"varint"`
+	ServerAddress   string `mc:"string,max=255"`
 	ServerPort      uint16
-	NextState       int32
+	NextState       int32 `mc:"varint"`
 }
 
 func (h *Handshake) ReadFrom(r io.Reader) (int64, error) {
-	var protocolVersion types.VarInt
-	var serverAddress types.String
-	var serverPort types.UnsignedShort
-	var nextState types.VarInt
-
-	n, err := stream.ReadAll(r, &protocolVersion, &serverAddress, &serverPort, &nextState)
-	if err != nil {
-		return n, err
-	}
-
-	h.ProtocolVersion = int32(protocolVersion)
-	h.ServerAddress = string(serverAddress)
-	h.ServerPort = uint16(serverPort)
-	h.NextState = int32(nextState)
-	return n, nil
+	return codec.Read(r, h)
 }
 
 func (h *Handshake) WriteTo(w io.Writer) (int64, error) {
-	protocolVersion := types.VarInt(h.ProtocolVersion)
-	serverAddress := types.String(h.ServerAddress)
-	serverPort := types.UnsignedShort(h.ServerPort)
-	nextState := types.VarInt(h.NextState)
-	return stream.WriteAll(w, &protocolVersion, &serverAddress, &serverPort, &nextState)
+	return codec.Write(w, h)
 }
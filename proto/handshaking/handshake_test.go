@@ -51,6 +51,14 @@ func TestHandshake_ReadFrom(t *testing.T) {
 			wantErr:      true,
 			wantModified: handshaking.Handshake{},
 		},
+		{
+			name:         "Invalid handshake (server address too long)",
+			fields:       fields{},
+			args:         args{bytes.NewReader(append([]byte{0x04, 0x80, 0x02}, bytes.Repeat([]byte{'a'}, 256)...))},
+			want:         259,
+			wantErr:      true,
+			wantModified: handshaking.Handshake{},
+		},
 		{
 			name:         "Invalid handshake (malformed VarInt)",
 			fields:       fields{},
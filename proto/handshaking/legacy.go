@@ -0,0 +1,209 @@
+package handshaking
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	LegacyPingID         byte = 0xFE
+	LegacyPingPayload    byte = 0x01
+	LegacyPluginID       byte = 0xFA
+	LegacyPingResponseID byte = 0xFF
+
+	legacyPingChannel = "MC|PingHost"
+)
+
+var (
+	ErrInvalidLegacyPing = errors.New("invalid legacy ping")
+)
+
+// LegacyPing is the 0xFE 0x01 server list ping sent by clients before 1.7.
+type LegacyPing struct{}
+
+func (l *LegacyPing) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 2)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	if buffer[0] != LegacyPingID || buffer[1] != LegacyPingPayload {
+		return int64(n), ErrInvalidLegacyPing
+	}
+	return int64(n), nil
+}
+
+func (l *LegacyPing) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write([]byte{LegacyPingID, LegacyPingPayload})
+	return int64(n), err
+}
+
+// LegacyPingHost is the MC|PingHost plugin message that 1.6 clients send
+// after LegacyPing.
+type LegacyPingHost struct {
+	ProtocolVersion byte
+	ServerAddress   string
+	ServerPort      int32
+}
+
+func (l *LegacyPingHost) ReadFrom(r io.Reader) (int64, error) {
+	lr := &legacyReader{r: r}
+	if lr.byte() != LegacyPluginID {
+		return lr.n, firstErr(lr.err, ErrInvalidLegacyPing)
+	}
+	if lr.string() != legacyPingChannel {
+		return lr.n, firstErr(lr.err, ErrInvalidLegacyPing)
+	}
+	lr.short()
+	protocolVersion := lr.byte()
+	serverAddress := lr.string()
+	serverPort := lr.int()
+	if lr.err != nil {
+		return lr.n, lr.err
+	}
+
+	l.ProtocolVersion = protocolVersion
+	l.ServerAddress = serverAddress
+	l.ServerPort = serverPort
+	return lr.n, nil
+}
+
+func (l *LegacyPingHost) WriteTo(w io.Writer) (int64, error) {
+	address := encodeUTF16(l.ServerAddress)
+	buffer := []byte{LegacyPluginID}
+	buffer = appendLegacyString(buffer, legacyPingChannel)
+	buffer = binary.BigEndian.AppendUint16(buffer, uint16(7+len(address)))
+	buffer = append(buffer, l.ProtocolVersion)
+	buffer = binary.BigEndian.AppendUint16(buffer, uint16(len(address)/2))
+	buffer = append(buffer, address...)
+	buffer = binary.BigEndian.AppendUint32(buffer, uint32(l.ServerPort))
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
+
+// LegacyPingResponse is the kick packet that carries the server list
+// entry for legacy clients.
+type LegacyPingResponse struct {
+	ProtocolVersion int32
+	Version         string
+	MOTD            string
+	Online          int
+	Max             int
+}
+
+func (l *LegacyPingResponse) ReadFrom(r io.Reader) (int64, error) {
+	lr := &legacyReader{r: r}
+	if lr.byte() != LegacyPingResponseID {
+		return lr.n, firstErr(lr.err, ErrInvalidLegacyPing)
+	}
+	s := lr.string()
+	if lr.err != nil {
+		return lr.n, lr.err
+	}
+
+	fields := strings.Split(s, "\x00")
+	if len(fields) != 6 || fields[0] != "§1" {
+		return lr.n, ErrInvalidLegacyPing
+	}
+	protocolVersion, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil {
+		return lr.n, ErrInvalidLegacyPing
+	}
+	online, err := strconv.Atoi(fields[4])
+	if err != nil {
+		return lr.n, ErrInvalidLegacyPing
+	}
+	max, err := strconv.Atoi(fields[5])
+	if err != nil {
+		return lr.n, ErrInvalidLegacyPing
+	}
+
+	l.ProtocolVersion = int32(protocolVersion)
+	l.Version = fields[2]
+	l.MOTD = fields[3]
+	l.Online = online
+	l.Max = max
+	return lr.n, nil
+}
+
+func (l *LegacyPingResponse) WriteTo(w io.Writer) (int64, error) {
+	s := strings.Join([]string{
+		"§1",
+		strconv.Itoa(int(l.ProtocolVersion)),
+		l.Version,
+		l.MOTD,
+		strconv.Itoa(l.Online),
+		strconv.Itoa(l.Max),
+	}, "\x00")
+	n, err := w.Write(appendLegacyString([]byte{LegacyPingResponseID}, s))
+	return int64(n), err
+}
+
+type legacyReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (lr *legacyReader) read(size int) []byte {
+	buffer := make([]byte, size)
+	if lr.err != nil {
+		return buffer
+	}
+	n, err := io.ReadFull(lr.r, buffer)
+	lr.n += int64(n)
+	lr.err = err
+	return buffer
+}
+
+func (lr *legacyReader) byte() byte {
+	return lr.read(1)[0]
+}
+
+func (lr *legacyReader) short() uint16 {
+	return binary.BigEndian.Uint16(lr.read(2))
+}
+
+func (lr *legacyReader) int() int32 {
+	return int32(binary.BigEndian.Uint32(lr.read(4)))
+}
+
+func (lr *legacyReader) string() string {
+	length := int(lr.short())
+	data := lr.read(length * 2)
+	if lr.err != nil {
+		return ""
+	}
+	units := make([]uint16, length)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+func encodeUTF16(s string) []byte {
+	var buffer []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		buffer = binary.BigEndian.AppendUint16(buffer, u)
+	}
+	return buffer
+}
+
+func appendLegacyString(buffer []byte, s string) []byte {
+	data := encodeUTF16(s)
+	buffer = binary.BigEndian.AppendUint16(buffer, uint16(len(data)/2))
+	return append(buffer, data...)
+}
+
+func firstErr(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package handshaking_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/handshaking"
+)
+
+func utf16be(s string) []byte {
+	var b []byte
+	for _, r := range s {
+		b = append(b, byte(r>>8), byte(r))
+	}
+	return b
+}
+
+var (
+	legacyResponseBytes = append([]byte{0xFF, 0x00, 0x13}, utf16be("§1\x00127\x001.21\x00Hi\x001\x0020")...)
+	legacyPingHostBytes = bytes.Join([][]byte{
+		{0xFA, 0x00, 0x0B},
+		utf16be("MC|PingHost"),
+		{0x00, 0x19, 0x4A, 0x00, 0x09},
+		utf16be("localhost"),
+		{0x00, 0x00, 0x63, 0xDD},
+	}, nil)
+)
+
+func TestLegacyPing_ReadFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       io.Reader
+		want    int64
+		wantErr bool
+	}{
+		{name: "Valid ping", r: bytes.NewReader([]byte{0xFE, 0x01}), want: 2},
+		{name: "Missing payload", r: bytes.NewReader([]byte{0xFE}), want: 1, wantErr: true},
+		{name: "Modern packet", r: bytes.NewReader([]byte{0x10, 0x00}), want: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &handshaking.LegacyPing{}
+			got, err := l.ReadFrom(tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LegacyPing.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LegacyPing.ReadFrom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLegacyPing_WriteTo(t *testing.T) {
+	w := &bytes.Buffer{}
+	got, err := (&handshaking.LegacyPing{}).WriteTo(w)
+	if err != nil {
+		t.Errorf("LegacyPing.WriteTo() error = %v", err)
+		return
+	}
+	if got != 2 || !bytes.Equal(w.Bytes(), []byte{0xFE, 0x01}) {
+		t.Errorf("LegacyPing.WriteTo() = %v, %v, want 2, [254 1]", got, w.Bytes())
+	}
+}
+
+func TestLegacyPingHost_ReadFrom(t *testing.T) {
+	tests := []struct {
+		name         string
+		r            io.Reader
+		want         int64
+		wantErr      bool
+		wantModified handshaking.LegacyPingHost
+	}{
+		{
+			name:         "Valid plugin message",
+			r:            bytes.NewReader(legacyPingHostBytes),
+			want:         int64(len(legacyPingHostBytes)),
+			wantModified: handshaking.LegacyPingHost{ProtocolVersion: 74, ServerAddress: "localhost", ServerPort: 25565},
+		},
+		{
+			name:    "Wrong channel",
+			r:       bytes.NewReader(append([]byte{0xFA, 0x00, 0x02}, utf16be("MC")...)),
+			want:    7,
+			wantErr: true,
+		},
+		{
+			name:    "Truncated",
+			r:       bytes.NewReader(legacyPingHostBytes[:30]),
+			want:    30,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &handshaking.LegacyPingHost{}
+			got, err := l.ReadFrom(tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LegacyPingHost.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LegacyPingHost.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(*l, tt.wantModified) {
+				t.Errorf("LegacyPingHost.ReadFrom() l = %v, wantModified %v", *l, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestLegacyPingHost_WriteTo(t *testing.T) {
+	l := &handshaking.LegacyPingHost{ProtocolVersion: 74, ServerAddress: "localhost", ServerPort: 25565}
+	w := &bytes.Buffer{}
+	got, err := l.WriteTo(w)
+	if err != nil {
+		t.Errorf("LegacyPingHost.WriteTo() error = %v", err)
+		return
+	}
+	if got != int64(len(legacyPingHostBytes)) {
+		t.Errorf("LegacyPingHost.WriteTo() = %v, want %v", got, len(legacyPingHostBytes))
+	}
+	if !bytes.Equal(w.Bytes(), legacyPingHostBytes) {
+		t.Errorf("LegacyPingHost.WriteTo() = %v, want %v", w.Bytes(), legacyPingHostBytes)
+	}
+}
+
+func TestLegacyPingResponse_ReadFrom(t *testing.T) {
+	tests := []struct {
+		name         string
+		r            io.Reader
+		want         int64
+		wantErr      bool
+		wantModified handshaking.LegacyPingResponse
+	}{
+		{
+			name:         "Valid response",
+			r:            bytes.NewReader(legacyResponseBytes),
+			want:         int64(len(legacyResponseBytes)),
+			wantModified: handshaking.LegacyPingResponse{ProtocolVersion: 127, Version: "1.21", MOTD: "Hi", Online: 1, Max: 20},
+		},
+		{
+			name:    "Pre-1.4 response",
+			r:       bytes.NewReader(append([]byte{0xFF, 0x00, 0x08}, utf16be("Hi§1§20")...)),
+			want:    17,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &handshaking.LegacyPingResponse{}
+			got, err := l.ReadFrom(tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LegacyPingResponse.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LegacyPingResponse.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(*l, tt.wantModified) {
+				t.Errorf("LegacyPingResponse.ReadFrom() l = %v, wantModified %v", *l, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestLegacyPingResponse_WriteTo(t *testing.T) {
+	l := &handshaking.LegacyPingResponse{ProtocolVersion: 127, Version: "1.21", MOTD: "Hi", Online: 1, Max: 20}
+	w := &bytes.Buffer{}
+	got, err := l.WriteTo(w)
+	if err != nil {
+		t.Errorf("LegacyPingResponse.WriteTo() error = %v", err)
+		return
+	}
+	if got != int64(len(legacyResponseBytes)) {
+		t.Errorf("LegacyPingResponse.WriteTo() = %v, want %v", got, len(legacyResponseBytes))
+	}
+	if !bytes.Equal(w.Bytes(), legacyResponseBytes) {
+		t.Errorf("LegacyPingResponse.WriteTo() = %v, want %v", w.Bytes(), legacyResponseBytes)
+	}
+}
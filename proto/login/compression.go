@@ -0,0 +1,21 @@
+package login
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+)
+
+const SetCompressionID = 0x03
+
+type SetCompression struct {
+	Threshold int32 `mc:"varint"`
+}
+
+func (s *SetCompression) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *SetCompression) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
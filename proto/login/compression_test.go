@@ -0,0 +1,95 @@
+package login_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/login"
+)
+
+func TestSetCompression_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantN        int64
+		wantErr      bool
+		wantModified login.SetCompression
+	}{
+		{
+			name:         "Default threshold",
+			args:         args{bytes.NewReader([]byte{0x80, 0x02})},
+			wantN:        2,
+			wantErr:      false,
+			wantModified: login.SetCompression{Threshold: 256},
+		},
+		{
+			name:         "Disabled",
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F})},
+			wantN:        5,
+			wantErr:      false,
+			wantModified: login.SetCompression{Threshold: -1},
+		},
+		{
+			name:         "Empty data",
+			args:         args{bytes.NewReader([]byte{})},
+			wantN:        0,
+			wantErr:      true,
+			wantModified: login.SetCompression{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &login.SetCompression{}
+			gotN, err := s.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetCompression.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("SetCompression.ReadFrom() = %v, want %v", gotN, tt.wantN)
+			}
+			if !reflect.DeepEqual(*s, tt.wantModified) {
+				t.Errorf("SetCompression.ReadFrom() s = %v, wantModified %v", *s, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestSetCompression_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       login.SetCompression
+		wantN   int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:    "Default threshold",
+			s:       login.SetCompression{Threshold: 256},
+			wantN:   2,
+			wantW:   []byte{0x80, 0x02},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			gotN, err := tt.s.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetCompression.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("SetCompression.WriteTo() = %v, want %v", gotN, tt.wantN)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("SetCompression.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
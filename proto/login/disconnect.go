@@ -0,0 +1,22 @@
+package login
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+const DisconnectID = 0x00
+
+type Disconnect struct {
+	Reason text.Component `mc:"json"`
+}
+
+func (d *Disconnect) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, d)
+}
+
+func (d *Disconnect) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, d)
+}
@@ -0,0 +1,89 @@
+package login_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func TestDisconnect_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantN        int64
+		wantErr      bool
+		wantModified login.Disconnect
+	}{
+		{
+			name:         "Valid reason",
+			args:         args{bytes.NewReader(append([]byte{0x0D}, []byte(`{"text":"Hi"}`)...))},
+			wantN:        14,
+			wantErr:      false,
+			wantModified: login.Disconnect{Reason: text.Plain("Hi")},
+		},
+		{
+			name:         "Truncated reason",
+			args:         args{bytes.NewReader([]byte{0x0D, '{'})},
+			wantN:        2,
+			wantErr:      true,
+			wantModified: login.Disconnect{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &login.Disconnect{}
+			gotN, err := d.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Disconnect.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("Disconnect.ReadFrom() = %v, want %v", gotN, tt.wantN)
+			}
+			if !reflect.DeepEqual(*d, tt.wantModified) {
+				t.Errorf("Disconnect.ReadFrom() d = %v, wantModified %v", *d, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestDisconnect_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       login.Disconnect
+		wantN   int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:    "Valid reason",
+			d:       login.Disconnect{Reason: text.Plain("Hi")},
+			wantN:   14,
+			wantW:   append([]byte{0x0D}, []byte(`{"text":"Hi"}`)...),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			gotN, err := tt.d.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Disconnect.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("Disconnect.WriteTo() = %v, want %v", gotN, tt.wantN)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Disconnect.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
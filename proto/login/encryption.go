@@ -0,0 +1,40 @@
+package login
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+)
+
+const (
+	EncryptionRequestID  = 0x01
+	EncryptionResponseID = 0x01
+)
+
+type EncryptionRequest struct {
+	ServerID           string `mc:"string,max=20"`
+	PublicKey          []byte
+	VerifyToken        []byte
+	ShouldAuthenticate bool
+}
+
+func (e *EncryptionRequest) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, e)
+}
+
+func (e *EncryptionRequest) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, e)
+}
+
+type EncryptionResponse struct {
+	SharedSecret []byte
+	VerifyToken  []byte
+}
+
+func (e *EncryptionResponse) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, e)
+}
+
+func (e *EncryptionResponse) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, e)
+}
@@ -0,0 +1,182 @@
+package login_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/login"
+)
+
+func TestEncryptionRequest_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantN        int64
+		wantErr      bool
+		wantModified login.EncryptionRequest
+	}{
+		{
+			name:    "Valid request",
+			args:    args{bytes.NewReader([]byte{0x00, 0x02, 0xAA, 0xBB, 0x04, 0x01, 0x02, 0x03, 0x04, 0x01})},
+			wantN:   10,
+			wantErr: false,
+			wantModified: login.EncryptionRequest{
+				ServerID:           "",
+				PublicKey:          []byte{0xAA, 0xBB},
+				VerifyToken:        []byte{0x01, 0x02, 0x03, 0x04},
+				ShouldAuthenticate: true,
+			},
+		},
+		{
+			name:         "Missing authenticate flag",
+			args:         args{bytes.NewReader([]byte{0x00, 0x02, 0xAA, 0xBB, 0x04, 0x01, 0x02, 0x03, 0x04})},
+			wantN:        9,
+			wantErr:      true,
+			wantModified: login.EncryptionRequest{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &login.EncryptionRequest{}
+			gotN, err := e.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncryptionRequest.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("EncryptionRequest.ReadFrom() = %v, want %v", gotN, tt.wantN)
+			}
+			if !reflect.DeepEqual(*e, tt.wantModified) {
+				t.Errorf("EncryptionRequest.ReadFrom() e = %v, wantModified %v", *e, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestEncryptionRequest_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		e       login.EncryptionRequest
+		wantN   int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name: "Valid request",
+			e: login.EncryptionRequest{
+				ServerID:           "",
+				PublicKey:          []byte{0xAA, 0xBB},
+				VerifyToken:        []byte{0x01, 0x02, 0x03, 0x04},
+				ShouldAuthenticate: true,
+			},
+			wantN:   10,
+			wantW:   []byte{0x00, 0x02, 0xAA, 0xBB, 0x04, 0x01, 0x02, 0x03, 0x04, 0x01},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			gotN, err := tt.e.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncryptionRequest.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("EncryptionRequest.WriteTo() = %v, want %v", gotN, tt.wantN)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("EncryptionRequest.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestEncryptionResponse_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantN        int64
+		wantErr      bool
+		wantModified login.EncryptionResponse
+	}{
+		{
+			name:    "Valid response",
+			args:    args{bytes.NewReader([]byte{0x02, 0xAA, 0xBB, 0x01, 0xCC})},
+			wantN:   5,
+			wantErr: false,
+			wantModified: login.EncryptionResponse{
+				SharedSecret: []byte{0xAA, 0xBB},
+				VerifyToken:  []byte{0xCC},
+			},
+		},
+		{
+			name:         "Truncated verify token",
+			args:         args{bytes.NewReader([]byte{0x02, 0xAA, 0xBB, 0x02, 0xCC})},
+			wantN:        5,
+			wantErr:      true,
+			wantModified: login.EncryptionResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &login.EncryptionResponse{}
+			gotN, err := e.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncryptionResponse.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("EncryptionResponse.ReadFrom() = %v, want %v", gotN, tt.wantN)
+			}
+			if !reflect.DeepEqual(*e, tt.wantModified) {
+				t.Errorf("EncryptionResponse.ReadFrom() e = %v, wantModified %v", *e, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestEncryptionResponse_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		e       login.EncryptionResponse
+		wantN   int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name: "Valid response",
+			e: login.EncryptionResponse{
+				SharedSecret: []byte{0xAA, 0xBB},
+				VerifyToken:  []byte{0xCC},
+			},
+			wantN:   5,
+			wantW:   []byte{0x02, 0xAA, 0xBB, 0x01, 0xCC},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			gotN, err := tt.e.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EncryptionResponse.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("EncryptionResponse.WriteTo() = %v, want %v", gotN, tt.wantN)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("EncryptionResponse.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
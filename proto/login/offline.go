@@ -0,0 +1,16 @@
+package login
+
+import (
+	"crypto/md5"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+// OfflineUUID returns the name-based (version 3) UUID that vanilla servers
+// assign to players when online mode is disabled.
+func OfflineUUID(name string) types.UUID {
+	u := types.UUID(md5.Sum([]byte("OfflinePlayer:" + name)))
+	u[6] = u[6]&0x0f | 0x30
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
@@ -0,0 +1,23 @@
+package login_test
+
+import (
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/login"
+)
+
+func TestOfflineUUID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Notch", want: "b50ad385-829d-3141-a216-7e7d7539ba7f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := login.OfflineUUID(tt.name).String(); got != tt.want {
+				t.Errorf("OfflineUUID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package login
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const LoginStartID = 0x00
+
+const MaxUsernameLength = 16
+
+type LoginStart struct {
+	Name       string `mc:"string,max=16"`
+	PlayerUUID types.UUID
+}
+
+func (l *LoginStart) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, l)
+}
+
+func (l *LoginStart) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, l)
+}
@@ -0,0 +1,98 @@
+package login_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+var testUUID = types.UUID{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5}
+
+func TestLoginStart_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantN        int64
+		wantErr      bool
+		wantModified login.LoginStart
+	}{
+		{
+			name:         "Valid login start",
+			args:         args{bytes.NewReader(append([]byte{0x05, 'N', 'o', 't', 'c', 'h'}, testUUID[:]...))},
+			wantN:        22,
+			wantErr:      false,
+			wantModified: login.LoginStart{Name: "Notch", PlayerUUID: testUUID},
+		},
+		{
+			name:         "Username too long",
+			args:         args{bytes.NewReader(append(append([]byte{0x11}, "ThisNameIsTooLong"...), testUUID[:]...))},
+			wantN:        18,
+			wantErr:      true,
+			wantModified: login.LoginStart{},
+		},
+		{
+			name:         "Missing UUID",
+			args:         args{bytes.NewReader([]byte{0x05, 'N', 'o', 't', 'c', 'h'})},
+			wantN:        6,
+			wantErr:      true,
+			wantModified: login.LoginStart{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &login.LoginStart{}
+			gotN, err := l.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginStart.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("LoginStart.ReadFrom() = %v, want %v", gotN, tt.wantN)
+			}
+			if !reflect.DeepEqual(*l, tt.wantModified) {
+				t.Errorf("LoginStart.ReadFrom() l = %v, wantModified %v", *l, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestLoginStart_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		l       login.LoginStart
+		wantN   int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:    "Valid login start",
+			l:       login.LoginStart{Name: "Notch", PlayerUUID: testUUID},
+			wantN:   22,
+			wantW:   append([]byte{0x05, 'N', 'o', 't', 'c', 'h'}, testUUID[:]...),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			gotN, err := tt.l.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginStart.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("LoginStart.WriteTo() = %v, want %v", gotN, tt.wantN)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("LoginStart.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package login
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const (
+	LoginSuccessID      = 0x02
+	LoginAcknowledgedID = 0x03
+)
+
+type Property struct {
+	Name      string
+	Value     string
+	Signature string `mc:"optional"`
+}
+
+type LoginSuccess struct {
+	UUID       types.UUID
+	Username   string     `mc:"string,max=16"`
+	Properties []Property `mc:"array"`
+}
+
+func (l *LoginSuccess) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, l)
+}
+
+func (l *LoginSuccess) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, l)
+}
+
+type LoginAcknowledged struct{}
+
+func (l *LoginAcknowledged) ReadFrom(r io.Reader) (int64, error) {
+	return 0, nil
+}
+
+func (l *LoginAcknowledged) WriteTo(w io.Writer) (int64, error) {
+	return 0, nil
+}
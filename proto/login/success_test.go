@@ -0,0 +1,156 @@
+package login_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/login"
+)
+
+func TestLoginSuccess_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantN        int64
+		wantErr      bool
+		wantModified login.LoginSuccess
+	}{
+		{
+			name:         "No properties",
+			args:         args{bytes.NewReader(append(testUUID[:], 0x05, 'N', 'o', 't', 'c', 'h', 0x00))},
+			wantN:        23,
+			wantErr:      false,
+			wantModified: login.LoginSuccess{UUID: testUUID, Username: "Notch"},
+		},
+		{
+			name: "Signed and unsigned properties",
+			args: args{bytes.NewReader(append(testUUID[:],
+				0x05, 'N', 'o', 't', 'c', 'h', 0x02,
+				0x01, 'a', 0x01, 'b', 0x00,
+				0x01, 'c', 0x01, 'd', 0x01, 0x01, 'e',
+			))},
+			wantN:   35,
+			wantErr: false,
+			wantModified: login.LoginSuccess{
+				UUID:     testUUID,
+				Username: "Notch",
+				Properties: []login.Property{
+					{Name: "a", Value: "b"},
+					{Name: "c", Value: "d", Signature: "e"},
+				},
+			},
+		},
+		{
+			name:         "Negative property count",
+			args:         args{bytes.NewReader(append(testUUID[:], 0x05, 'N', 'o', 't', 'c', 'h', 0xFF, 0xFF, 0xFF, 0xFF, 0x0F))},
+			wantN:        27,
+			wantErr:      true,
+			wantModified: login.LoginSuccess{},
+		},
+		{
+			name:         "Truncated property",
+			args:         args{bytes.NewReader(append(testUUID[:], 0x05, 'N', 'o', 't', 'c', 'h', 0x01, 0x01, 'a'))},
+			wantN:        25,
+			wantErr:      true,
+			wantModified: login.LoginSuccess{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &login.LoginSuccess{}
+			gotN, err := l.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginSuccess.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("LoginSuccess.ReadFrom() = %v, want %v", gotN, tt.wantN)
+			}
+			if !reflect.DeepEqual(*l, tt.wantModified) {
+				t.Errorf("LoginSuccess.ReadFrom() l = %v, wantModified %v", *l, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestLoginSuccess_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		l       login.LoginSuccess
+		wantN   int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:    "No properties",
+			l:       login.LoginSuccess{UUID: testUUID, Username: "Notch"},
+			wantN:   23,
+			wantW:   append(testUUID[:], 0x05, 'N', 'o', 't', 'c', 'h', 0x00),
+			wantErr: false,
+		},
+		{
+			name: "Signed and unsigned properties",
+			l: login.LoginSuccess{
+				UUID:     testUUID,
+				Username: "Notch",
+				Properties: []login.Property{
+					{Name: "a", Value: "b"},
+					{Name: "c", Value: "d", Signature: "e"},
+				},
+			},
+			wantN: 35,
+			wantW: append(testUUID[:],
+				0x05, 'N', 'o', 't', 'c', 'h', 0x02,
+				0x01, 'a', 0x01, 'b', 0x00,
+				0x01, 'c', 0x01, 'd', 0x01, 0x01, 'e',
+			),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			gotN, err := tt.l.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoginSuccess.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("LoginSuccess.WriteTo() = %v, want %v", gotN, tt.wantN)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("LoginSuccess.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestLoginAcknowledged_ReadFrom(t *testing.T) {
+	l := &login.LoginAcknowledged{}
+	gotN, err := l.ReadFrom(bytes.NewReader([]byte{}))
+	if err != nil {
+		t.Errorf("LoginAcknowledged.ReadFrom() error = %v", err)
+		return
+	}
+	if gotN != 0 {
+		t.Errorf("LoginAcknowledged.ReadFrom() = %v, want 0", gotN)
+	}
+}
+
+func TestLoginAcknowledged_WriteTo(t *testing.T) {
+	l := &login.LoginAcknowledged{}
+	w := &bytes.Buffer{}
+	gotN, err := l.WriteTo(w)
+	if err != nil {
+		t.Errorf("LoginAcknowledged.WriteTo() error = %v", err)
+		return
+	}
+	if gotN != 0 || w.Len() != 0 {
+		t.Errorf("LoginAcknowledged.WriteTo() = %v, %v, want 0, []", gotN, w.Bytes())
+	}
+}
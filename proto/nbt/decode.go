@@ -0,0 +1,438 @@
+package nbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/nonya123456/cobble/proto/stream"
+)
+
+// Unmarshal decodes the network encoding in data into v, which must be a
+// non-nil pointer. A lone TAG_End leaves v unchanged.
+func Unmarshal(data []byte, v any) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// Decoder reads tags from a stream without reading past the end of each
+// tag, so it can be used on a packet body holding further fields.
+type Decoder struct {
+	r   io.Reader
+	buf [8]byte
+	n   int64
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// InputOffset returns the number of bytes read so far.
+func (d *Decoder) InputOffset() int64 {
+	return d.n
+}
+
+// Decode reads a tag in the network format into v.
+func (d *Decoder) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("nbt: Decode requires a non-nil pointer")
+	}
+	typ, err := d.readType()
+	if err != nil || typ == TagEnd {
+		return err
+	}
+	t, err := d.readPayload(typ, 0)
+	if err != nil {
+		return err
+	}
+	return assign(t, rv)
+}
+
+// DecodeNamed reads a tag in the file format into v and returns the name
+// of the root tag.
+func (d *Decoder) DecodeNamed(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return "", errors.New("nbt: DecodeNamed requires a non-nil pointer")
+	}
+	typ, err := d.readType()
+	if err != nil || typ == TagEnd {
+		return "", err
+	}
+	name, err := d.readString()
+	if err != nil {
+		return "", err
+	}
+	t, err := d.readPayload(typ, 0)
+	if err != nil {
+		return "", err
+	}
+	return name, assign(t, rv)
+}
+
+func (d *Decoder) read(n int) ([]byte, error) {
+	m, err := io.ReadFull(d.r, d.buf[:n])
+	d.n += int64(m)
+	return d.buf[:n], err
+}
+
+func (d *Decoder) readBytes(n int) ([]byte, error) {
+	b, err := stream.ReadBytes(d.r, n)
+	d.n += int64(len(b))
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+func (d *Decoder) readType() (TagType, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	if typ := TagType(b[0]); typ <= TagLongArray {
+		return typ, nil
+	}
+	return 0, ErrInvalidTagType
+}
+
+func (d *Decoder) readString() (string, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+	b, err = d.readBytes(int(binary.BigEndian.Uint16(b)))
+	if err != nil {
+		return "", err
+	}
+	return decodeString(b)
+}
+
+func (d *Decoder) readLength() (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, unexpectedEOF(err)
+	}
+	n := int32(binary.BigEndian.Uint32(b))
+	if n < 0 {
+		return 0, ErrInvalidLength
+	}
+	return int(n), nil
+}
+
+func (d *Decoder) readPayload(typ TagType, depth int) (tag, error) {
+	switch typ {
+	case TagByte, TagShort, TagInt, TagLong, TagFloat, TagDouble:
+		b, err := d.read(numberSize(typ))
+		if err != nil {
+			return tag{}, unexpectedEOF(err)
+		}
+		return number(typ, b), nil
+	case TagByteArray:
+		n, err := d.readLength()
+		if err != nil {
+			return tag{}, err
+		}
+		b, err := d.readBytes(n)
+		return tag{typ, b}, err
+	case TagString:
+		s, err := d.readString()
+		return tag{typ, s}, err
+	case TagIntArray:
+		n, err := d.readLength()
+		if err != nil {
+			return tag{}, err
+		}
+		b, err := d.readBytes(n * 4)
+		if err != nil {
+			return tag{}, err
+		}
+		a := make([]int32, n)
+		for i := range a {
+			a[i] = int32(binary.BigEndian.Uint32(b[i*4:]))
+		}
+		return tag{typ, a}, nil
+	case TagLongArray:
+		n, err := d.readLength()
+		if err != nil {
+			return tag{}, err
+		}
+		b, err := d.readBytes(n * 8)
+		if err != nil {
+			return tag{}, err
+		}
+		a := make([]int64, n)
+		for i := range a {
+			a[i] = int64(binary.BigEndian.Uint64(b[i*8:]))
+		}
+		return tag{typ, a}, nil
+	}
+
+	if depth >= MaxDepth {
+		return tag{}, ErrMaxDepth
+	}
+	switch typ {
+	case TagList:
+		elem, err := d.readType()
+		if err != nil {
+			return tag{}, unexpectedEOF(err)
+		}
+		n, err := d.readLength()
+		if err != nil {
+			return tag{}, err
+		}
+		if elem == TagEnd && n > 0 {
+			return tag{}, ErrInvalidTagType
+		}
+		l := list{elem: elem}
+		for range n {
+			item, err := d.readPayload(elem, depth+1)
+			if err != nil {
+				return tag{}, err
+			}
+			l.items = append(l.items, item)
+		}
+		return tag{typ, l}, nil
+	case TagCompound:
+		fields := []field{}
+		for {
+			ft, err := d.readType()
+			if err != nil {
+				return tag{}, unexpectedEOF(err)
+			}
+			if ft == TagEnd {
+				return tag{typ, fields}, nil
+			}
+			name, err := d.readString()
+			if err != nil {
+				return tag{}, err
+			}
+			t, err := d.readPayload(ft, depth+1)
+			if err != nil {
+				return tag{}, err
+			}
+			fields = append(fields, field{name, t})
+		}
+	}
+	return tag{}, ErrInvalidTagType
+}
+
+func numberSize(typ TagType) int {
+	switch typ {
+	case TagByte:
+		return 1
+	case TagShort:
+		return 2
+	case TagInt, TagFloat:
+		return 4
+	default:
+		return 8
+	}
+}
+
+func number(typ TagType, b []byte) tag {
+	switch typ {
+	case TagByte:
+		return tag{typ, int8(b[0])}
+	case TagShort:
+		return tag{typ, int16(binary.BigEndian.Uint16(b))}
+	case TagInt:
+		return tag{typ, int32(binary.BigEndian.Uint32(b))}
+	case TagLong:
+		return tag{typ, int64(binary.BigEndian.Uint64(b))}
+	case TagFloat:
+		return tag{typ, math.Float32frombits(binary.BigEndian.Uint32(b))}
+	default:
+		return tag{typ, math.Float64frombits(binary.BigEndian.Uint64(b))}
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+var unmarshalerType = reflect.TypeFor[Unmarshaler]()
+
+// assign stores t in v, converting between numeric types where the value
+// fits.
+func assign(t tag, v reflect.Value) error {
+	if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(unmarshalerType) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalNBT(t.generic())
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return assign(t, v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(t.generic()))
+			return nil
+		}
+	case reflect.Bool:
+		if i, ok := t.integer(); ok {
+			v.SetBool(i != 0)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := t.integer(); ok && !v.OverflowInt(i) {
+			v.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, ok := t.unsigned(); ok && !v.OverflowUint(u) {
+			v.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := t.float(); ok {
+			v.SetFloat(f)
+			return nil
+		}
+	case reflect.String:
+		if s, ok := t.v.(string); ok {
+			v.SetString(s)
+			return nil
+		}
+	case reflect.Slice:
+		if b, ok := t.v.([]byte); ok && v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes(bytes.Clone(b))
+			return nil
+		}
+		if items, ok := t.elements(); ok {
+			s := reflect.MakeSlice(v.Type(), len(items), len(items))
+			for i, item := range items {
+				if err := assign(item, s.Index(i)); err != nil {
+					return err
+				}
+			}
+			v.Set(s)
+			return nil
+		}
+	case reflect.Array:
+		if items, ok := t.elements(); ok {
+			for i := range v.Len() {
+				if i >= len(items) {
+					v.Index(i).SetZero()
+				} else if err := assign(items[i], v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		fields, ok := t.v.([]field)
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(fields)))
+		}
+		for _, f := range fields {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := assign(f.tag, elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(f.name).Convert(v.Type().Key()), elem)
+		}
+		return nil
+	case reflect.Struct:
+		fields, ok := t.v.([]field)
+		if !ok {
+			break
+		}
+		structFields := cachedFields(v.Type())
+		for _, f := range fields {
+			i := slices.IndexFunc(structFields, func(sf structField) bool { return sf.name == f.name })
+			if i < 0 {
+				i = slices.IndexFunc(structFields, func(sf structField) bool { return strings.EqualFold(sf.name, f.name) })
+			}
+			if i < 0 {
+				continue
+			}
+			if err := assign(f.tag, v.FieldByIndex(structFields[i].index)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &UnmarshalTypeError{Tag: t.typ, Type: v.Type()}
+}
+
+func (t tag) integer() (int64, bool) {
+	switch v := t.v.(type) {
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// unsigned reinterprets integer tags at their own width, so that a
+// TAG_Byte of -1 decodes into a uint8 as 255.
+func (t tag) unsigned() (uint64, bool) {
+	switch v := t.v.(type) {
+	case int8:
+		return uint64(uint8(v)), true
+	case int16:
+		return uint64(uint16(v)), true
+	case int32:
+		return uint64(uint32(v)), true
+	case int64:
+		return uint64(v), true
+	}
+	return 0, false
+}
+
+func (t tag) float() (float64, bool) {
+	switch v := t.v.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	i, ok := t.integer()
+	return float64(i), ok
+}
+
+// elements returns the items of a List or array tag.
+func (t tag) elements() ([]tag, bool) {
+	switch v := t.v.(type) {
+	case list:
+		return v.items, true
+	case []byte:
+		items := make([]tag, len(v))
+		for i, b := range v {
+			items[i] = tag{TagByte, int8(b)}
+		}
+		return items, true
+	case []int32:
+		items := make([]tag, len(v))
+		for i, n := range v {
+			items[i] = tag{TagInt, n}
+		}
+		return items, true
+	case []int64:
+		items := make([]tag, len(v))
+		for i, n := range v {
+			items[i] = tag{TagLong, n}
+		}
+		return items, true
+	}
+	return nil, false
+}
@@ -0,0 +1,243 @@
+package nbt_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+)
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		v       any
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "Generic compound",
+			data: []byte{0x0A, 0x08, 0x00, 0x01, 'a', 0x00, 0x01, 'x', 0x09, 0x00, 0x01, 'l', 0x01, 0x00, 0x00, 0x00, 0x01, 0x05, 0x00},
+			v:    new(any),
+			want: map[string]any{"a": "x", "l": []any{int8(5)}},
+		},
+		{
+			name: "Struct",
+			data: []byte{
+				0x0A,
+				0x08, 0x00, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x01, 'a',
+				0x01, 0x00, 0x05, 'l', 'e', 'v', 'e', 'l', 0x02,
+				0x09, 0x00, 0x06, 's', 'c', 'o', 'r', 'e', 's', 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x07,
+				0x08, 0x00, 0x07, 'u', 'n', 'k', 'n', 'o', 'w', 'n', 0x00, 0x00,
+				0x00,
+			},
+			v:    new(testPlayer),
+			want: testPlayer{Name: "a", Level: 2, Scores: []int32{7}},
+		},
+		{
+			name: "Case-insensitive field name",
+			data: []byte{0x0A, 0x03, 0x00, 0x01, 'x', 0x00, 0x00, 0x00, 0x05, 0x00},
+			v:    new(testPos),
+			want: testPos{X: 5},
+		},
+		{
+			name: "Unsigned byte",
+			data: []byte{0x01, 0xFF},
+			v:    new(uint8),
+			want: uint8(255),
+		},
+		{
+			name: "Widening integer",
+			data: []byte{0x02, 0xFF, 0xFE},
+			v:    new(int64),
+			want: int64(-2),
+		},
+		{
+			name: "Bool",
+			data: []byte{0x01, 0x01},
+			v:    new(bool),
+			want: true,
+		},
+		{
+			name: "Byte array into int8 slice",
+			data: []byte{0x07, 0x00, 0x00, 0x00, 0x02, 0xFF, 0x01},
+			v:    new([]int8),
+			want: []int8{-1, 1},
+		},
+		{
+			name: "Int array into fixed array",
+			data: []byte{0x0B, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01},
+			v:    &[2]int32{5, 5},
+			want: [2]int32{1, 0},
+		},
+		{
+			name: "Long array",
+			data: []byte{0x0C, 0x00, 0x00, 0x00, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+			v:    new([]int64),
+			want: []int64{-1},
+		},
+		{
+			name: "Pointer field",
+			data: []byte{0x0A, 0x03, 0x00, 0x01, 'a', 0x00, 0x00, 0x00, 0x05, 0x00},
+			v:    new(map[string]*int32),
+			want: map[string]*int32{"a": newInt32(5)},
+		},
+		{
+			name: "End leaves value unchanged",
+			data: []byte{0x00},
+			v:    newString("unchanged"),
+			want: "unchanged",
+		},
+		{
+			name:    "Overflow",
+			data:    []byte{0x03, 0x00, 0x00, 0x01, 0x00},
+			v:       new(int8),
+			wantErr: true,
+		},
+		{
+			name:    "Type mismatch",
+			data:    []byte{0x08, 0x00, 0x01, 'a'},
+			v:       new(int32),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid tag type",
+			data:    []byte{0x0D},
+			v:       new(any),
+			wantErr: true,
+		},
+		{
+			name:    "Negative array length",
+			data:    []byte{0x07, 0xFF, 0xFF, 0xFF, 0xFF},
+			v:       new(any),
+			wantErr: true,
+		},
+		{
+			name:    "List of End with elements",
+			data:    []byte{0x09, 0x00, 0x00, 0x00, 0x00, 0x01},
+			v:       new(any),
+			wantErr: true,
+		},
+		{
+			name:    "Forged array length",
+			data:    []byte{0x0C, 0x7F, 0xFF, 0xFF, 0xFF, 0x00},
+			v:       new(any),
+			wantErr: true,
+		},
+		{
+			name:    "Truncated compound",
+			data:    []byte{0x0A, 0x01, 0x00, 0x01, 'a'},
+			v:       new(any),
+			wantErr: true,
+		},
+		{
+			name:    "Invalid modified UTF-8",
+			data:    []byte{0x08, 0x00, 0x01, 0xC0},
+			v:       new(any),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := nbt.Unmarshal(tt.data, tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := reflect.ValueOf(tt.v).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_maxDepth(t *testing.T) {
+	data := []byte{byte(nbt.TagList)}
+	for range nbt.MaxDepth + 1 {
+		data = append(data, byte(nbt.TagList), 0x00, 0x00, 0x00, 0x01)
+	}
+	var v any
+	if err := nbt.Unmarshal(data, &v); !errors.Is(err, nbt.ErrMaxDepth) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, nbt.ErrMaxDepth)
+	}
+}
+
+func TestUnmarshal_roundTrip(t *testing.T) {
+	type all struct {
+		Byte      int8
+		Short     int16
+		Int       int32
+		Long      int64
+		Float     float32
+		Double    float64
+		String    string
+		ByteArray []byte
+		IntArray  []int32
+		LongArray []int64
+		List      []testPos
+		Compound  map[string]string
+	}
+	want := all{
+		Byte:      -1,
+		Short:     300,
+		Int:       -70000,
+		Long:      1 << 40,
+		Float:     0.5,
+		Double:    -0.25,
+		String:    "\x00你好😀",
+		ByteArray: []byte{1, 2, 3},
+		IntArray:  []int32{-1, 1},
+		LongArray: []int64{1 << 50},
+		List:      []testPos{{X: 1}, {Y: 2}},
+		Compound:  map[string]string{"a": "b"},
+	}
+	data, err := nbt.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got all
+	if err := nbt.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecoder_InputOffset(t *testing.T) {
+	r := bytes.NewReader([]byte{0x08, 0x00, 0x01, 'a', 0xAA, 0xBB})
+	d := nbt.NewDecoder(r)
+	var s string
+	if err := d.Decode(&s); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if got := d.InputOffset(); got != 4 {
+		t.Errorf("Decoder.InputOffset() = %v, want 4", got)
+	}
+	if rest, _ := io.ReadAll(r); !bytes.Equal(rest, []byte{0xAA, 0xBB}) {
+		t.Errorf("Decoder.Decode() left %v, want [170 187]", rest)
+	}
+}
+
+func TestUnmarshal_typeError(t *testing.T) {
+	var v int32
+	err := nbt.Unmarshal([]byte{0x08, 0x00, 0x00}, &v)
+	var typeErr *nbt.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Tag != nbt.TagString || typeErr.Type != reflect.TypeFor[int32]() {
+		t.Errorf("Unmarshal() error = %v, want UnmarshalTypeError for TAG_String into int32", err)
+	}
+}
+
+func newString(s string) *string {
+	return &s
+}
+
+func newInt32(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,348 @@
+package nbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Marshal returns the network encoding of v: a tag type followed by an
+// unnamed payload. A nil v encodes as a lone TAG_End.
+func Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type Encoder struct {
+	w io.Writer
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes v in the network format.
+func (e *Encoder) Encode(v any) error {
+	t, err := encodeValue(reflect.ValueOf(v), false)
+	if err != nil {
+		return err
+	}
+	b, err := appendPayload([]byte{byte(t.typ)}, t)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+// EncodeNamed writes v in the file format, where the root tag carries a
+// name. The root is usually a Compound.
+func (e *Encoder) EncodeNamed(name string, v any) error {
+	t, err := encodeValue(reflect.ValueOf(v), false)
+	if err != nil {
+		return err
+	}
+	if t.typ == TagEnd {
+		_, err := e.w.Write([]byte{byte(TagEnd)})
+		return err
+	}
+	b, err := appendString([]byte{byte(t.typ)}, name)
+	if err != nil {
+		return err
+	}
+	if b, err = appendPayload(b, t); err != nil {
+		return err
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+var marshalerType = reflect.TypeFor[Marshaler]()
+
+// encodeValue converts v to a tag. Nil pointers and interfaces become
+// TAG_End, which callers either skip or reject.
+func encodeValue(v reflect.Value, asList bool) (tag, error) {
+	if !v.IsValid() {
+		return tag{typ: TagEnd}, nil
+	}
+	if m, ok := marshaler(v); ok {
+		x, err := m.MarshalNBT()
+		if err != nil {
+			return tag{}, err
+		}
+		return encodeValue(reflect.ValueOf(x), false)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return tag{typ: TagEnd}, nil
+		}
+		return encodeValue(v.Elem(), asList)
+	case reflect.Bool:
+		var b int8
+		if v.Bool() {
+			b = 1
+		}
+		return tag{TagByte, b}, nil
+	case reflect.Int8:
+		return tag{TagByte, int8(v.Int())}, nil
+	case reflect.Int16:
+		return tag{TagShort, int16(v.Int())}, nil
+	case reflect.Int32:
+		return tag{TagInt, int32(v.Int())}, nil
+	case reflect.Int64:
+		return tag{TagLong, v.Int()}, nil
+	case reflect.Int:
+		i := v.Int()
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return tag{}, fmt.Errorf("nbt: int %d overflows TAG_Int", i)
+		}
+		return tag{TagInt, int32(i)}, nil
+	case reflect.Uint8:
+		return tag{TagByte, int8(v.Uint())}, nil
+	case reflect.Uint16:
+		return tag{TagShort, int16(v.Uint())}, nil
+	case reflect.Uint32:
+		return tag{TagInt, int32(v.Uint())}, nil
+	case reflect.Uint64:
+		return tag{TagLong, int64(v.Uint())}, nil
+	case reflect.Uint:
+		u := v.Uint()
+		if u > math.MaxUint32 {
+			return tag{}, fmt.Errorf("nbt: uint %d overflows TAG_Int", u)
+		}
+		return tag{TagInt, int32(u)}, nil
+	case reflect.Float32:
+		return tag{TagFloat, float32(v.Float())}, nil
+	case reflect.Float64:
+		return tag{TagDouble, v.Float()}, nil
+	case reflect.String:
+		return tag{TagString, v.String()}, nil
+	case reflect.Slice, reflect.Array:
+		return encodeSequence(v, asList)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		fields := make([]field, 0, len(keys))
+		for _, k := range keys {
+			t, err := encodeValue(v.MapIndex(k), false)
+			if err != nil {
+				return tag{}, err
+			}
+			if t.typ != TagEnd {
+				fields = append(fields, field{k.String(), t})
+			}
+		}
+		return tag{TagCompound, fields}, nil
+	case reflect.Struct:
+		var fields []field
+		for _, f := range cachedFields(v.Type()) {
+			fv := v.FieldByIndex(f.index)
+			if f.omitEmpty && fv.IsZero() {
+				continue
+			}
+			t, err := encodeValue(fv, f.asList)
+			if err != nil {
+				return tag{}, err
+			}
+			if t.typ != TagEnd {
+				fields = append(fields, field{f.name, t})
+			}
+		}
+		return tag{TagCompound, fields}, nil
+	}
+	return tag{}, &UnsupportedTypeError{Type: v.Type()}
+}
+
+func marshaler(v reflect.Value) (Marshaler, bool) {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, false
+	}
+	if v.Type().Implements(marshalerType) {
+		return v.Interface().(Marshaler), true
+	}
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(marshalerType) {
+		return v.Addr().Interface().(Marshaler), true
+	}
+	return nil, false
+}
+
+func encodeSequence(v reflect.Value, asList bool) (tag, error) {
+	elem := v.Type().Elem()
+	if !asList && !elem.Implements(marshalerType) && !reflect.PointerTo(elem).Implements(marshalerType) {
+		switch elem.Kind() {
+		case reflect.Int8, reflect.Uint8:
+			b := make([]byte, v.Len())
+			for i := range b {
+				b[i] = byte(integer(v.Index(i)))
+			}
+			return tag{TagByteArray, b}, nil
+		case reflect.Int32, reflect.Uint32:
+			a := make([]int32, v.Len())
+			for i := range a {
+				a[i] = int32(integer(v.Index(i)))
+			}
+			return tag{TagIntArray, a}, nil
+		case reflect.Int64, reflect.Uint64:
+			a := make([]int64, v.Len())
+			for i := range a {
+				a[i] = integer(v.Index(i))
+			}
+			return tag{TagLongArray, a}, nil
+		}
+	}
+
+	l := list{elem: TagEnd, items: make([]tag, v.Len())}
+	for i := range l.items {
+		t, err := encodeValue(v.Index(i), false)
+		if err != nil {
+			return tag{}, err
+		}
+		if t.typ == TagEnd {
+			return tag{}, fmt.Errorf("nbt: nil element in list of %s", elem)
+		}
+		if i == 0 {
+			l.elem = t.typ
+		} else if t.typ != l.elem {
+			return tag{}, ErrMixedList
+		}
+		l.items[i] = t
+	}
+	return tag{TagList, l}, nil
+}
+
+func integer(v reflect.Value) int64 {
+	if v.CanInt() {
+		return v.Int()
+	}
+	return int64(v.Uint())
+}
+
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	asList    bool
+}
+
+var fieldCache sync.Map
+
+func cachedFields(t reflect.Type) []structField {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	fields, _ := fieldCache.LoadOrStore(t, typeFields(t))
+	return fields.([]structField)
+}
+
+// typeFields lists the encoded fields of t, inlining untagged embedded
+// structs.
+func typeFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tagValue := sf.Tag.Get("nbt")
+		if tagValue == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tagValue, ",")
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			for _, f := range typeFields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := structField{name: name, index: []int{i}}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "list":
+				f.asList = true
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func appendPayload(b []byte, t tag) ([]byte, error) {
+	switch v := t.v.(type) {
+	case nil:
+		return b, nil
+	case int8:
+		return append(b, byte(v)), nil
+	case int16:
+		return binary.BigEndian.AppendUint16(b, uint16(v)), nil
+	case int32:
+		return binary.BigEndian.AppendUint32(b, uint32(v)), nil
+	case int64:
+		return binary.BigEndian.AppendUint64(b, uint64(v)), nil
+	case float32:
+		return binary.BigEndian.AppendUint32(b, math.Float32bits(v)), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(v)), nil
+	case []byte:
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		return append(b, v...), nil
+	case string:
+		return appendString(b, v)
+	case list:
+		b = append(b, byte(v.elem))
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v.items)))
+		var err error
+		for _, item := range v.items {
+			if b, err = appendPayload(b, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case []field:
+		var err error
+		for _, f := range v {
+			b = append(b, byte(f.tag.typ))
+			if b, err = appendString(b, f.name); err != nil {
+				return nil, err
+			}
+			if b, err = appendPayload(b, f.tag); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, byte(TagEnd)), nil
+	case []int32:
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		for _, i := range v {
+			b = binary.BigEndian.AppendUint32(b, uint32(i))
+		}
+		return b, nil
+	case []int64:
+		b = binary.BigEndian.AppendUint32(b, uint32(len(v)))
+		for _, i := range v {
+			b = binary.BigEndian.AppendUint64(b, uint64(i))
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("nbt: invalid %s payload %T", t.typ, t.v)
+}
@@ -0,0 +1,220 @@
+package nbt_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+)
+
+type testPlayer struct {
+	Name    string  `nbt:"name"`
+	Health  float32 `nbt:"health"`
+	Level   int     `nbt:"level,omitempty"`
+	Scores  []int32 `nbt:"scores,list"`
+	Secret  string  `nbt:"-"`
+	private int
+}
+
+type testPos struct {
+	X, Y, Z int32
+}
+
+type testEntity struct {
+	testPos
+	ID string `nbt:"id"`
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "Nil",
+			v:    nil,
+			want: []byte{0x00},
+		},
+		{
+			name: "Byte from bool",
+			v:    true,
+			want: []byte{0x01, 0x01},
+		},
+		{
+			name: "Short",
+			v:    int16(-2),
+			want: []byte{0x02, 0xFF, 0xFE},
+		},
+		{
+			name: "Int",
+			v:    256,
+			want: []byte{0x03, 0x00, 0x00, 0x01, 0x00},
+		},
+		{
+			name: "Long",
+			v:    int64(1),
+			want: []byte{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			name: "Float",
+			v:    float32(1),
+			want: []byte{0x05, 0x3F, 0x80, 0x00, 0x00},
+		},
+		{
+			name: "Double",
+			v:    float64(-2),
+			want: []byte{0x06, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "Byte array",
+			v:    []byte{1, 2},
+			want: []byte{0x07, 0x00, 0x00, 0x00, 0x02, 0x01, 0x02},
+		},
+		{
+			name: "String",
+			v:    "hi",
+			want: []byte{0x08, 0x00, 0x02, 'h', 'i'},
+		},
+		{
+			name: "Modified UTF-8 string",
+			v:    "\x00😀",
+			want: []byte{0x08, 0x00, 0x08, 0xC0, 0x80, 0xED, 0xA0, 0xBD, 0xED, 0xB8, 0x80},
+		},
+		{
+			name: "List",
+			v:    []string{"a"},
+			want: []byte{0x09, 0x08, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 'a'},
+		},
+		{
+			name: "Empty list",
+			v:    []string{},
+			want: []byte{0x09, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "Compound from map with sorted keys",
+			v:    map[string]any{"b": int8(1), "a": "x"},
+			want: []byte{0x0A, 0x08, 0x00, 0x01, 'a', 0x00, 0x01, 'x', 0x01, 0x00, 0x01, 'b', 0x01, 0x00},
+		},
+		{
+			name: "Int array",
+			v:    []int32{1},
+			want: []byte{0x0B, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			name: "Long array",
+			v:    []uint64{1},
+			want: []byte{0x0C, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+		{
+			name: "Struct with tags",
+			v:    testPlayer{Name: "a", Health: 1, Scores: []int32{7}, Secret: "s", private: 1},
+			want: []byte{
+				0x0A,
+				0x08, 0x00, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x01, 'a',
+				0x05, 0x00, 0x06, 'h', 'e', 'a', 'l', 't', 'h', 0x3F, 0x80, 0x00, 0x00,
+				0x09, 0x00, 0x06, 's', 'c', 'o', 'r', 'e', 's', 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x07,
+				0x00,
+			},
+		},
+		{
+			name: "Embedded struct",
+			v:    testEntity{testPos: testPos{X: 1}, ID: "pig"},
+			want: []byte{
+				0x0A,
+				0x03, 0x00, 0x01, 'X', 0x00, 0x00, 0x00, 0x01,
+				0x03, 0x00, 0x01, 'Y', 0x00, 0x00, 0x00, 0x00,
+				0x03, 0x00, 0x01, 'Z', 0x00, 0x00, 0x00, 0x00,
+				0x08, 0x00, 0x02, 'i', 'd', 0x00, 0x03, 'p', 'i', 'g',
+				0x00,
+			},
+		},
+		{
+			name: "Nil field skipped",
+			v:    map[string]*int32{"a": nil},
+			want: []byte{0x0A, 0x00},
+		},
+		{
+			name:    "Mixed list",
+			v:       []any{int8(1), "a"},
+			wantErr: true,
+		},
+		{
+			name:    "Nil list element",
+			v:       []*int32{nil},
+			wantErr: true,
+		},
+		{
+			name:    "Int overflow",
+			v:       1 << 40,
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported type",
+			v:       complex64(1),
+			wantErr: true,
+		},
+		{
+			name:    "Non-string map key",
+			v:       map[int]string{1: "a"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nbt.Marshal(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Marshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Marshal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testColor struct {
+	R, G, B uint8
+}
+
+func (c testColor) MarshalNBT() (any, error) {
+	return int32(c.R)<<16 | int32(c.G)<<8 | int32(c.B), nil
+}
+
+func (c *testColor) UnmarshalNBT(v any) error {
+	rgb, _ := v.(int32)
+	c.R, c.G, c.B = uint8(rgb>>16), uint8(rgb>>8), uint8(rgb)
+	return nil
+}
+
+func TestMarshal_marshaler(t *testing.T) {
+	got, err := nbt.Marshal(map[string]testColor{"color": {R: 0x12, G: 0x34, B: 0x56}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x0A, 0x03, 0x00, 0x05, 'c', 'o', 'l', 'o', 'r', 0x00, 0x12, 0x34, 0x56, 0x00}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Marshal() = %v, want %v", got, want)
+	}
+
+	var decoded map[string]testColor
+	if err := nbt.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if c := decoded["color"]; c != (testColor{R: 0x12, G: 0x34, B: 0x56}) {
+		t.Errorf("Unmarshal() color = %v, want {18 52 86}", c)
+	}
+}
+
+func TestEncoder_EncodeNamed(t *testing.T) {
+	var buf bytes.Buffer
+	if err := nbt.NewEncoder(&buf).EncodeNamed("hello world", map[string]string{"name": "Bananrama"}); err != nil {
+		t.Fatalf("Encoder.EncodeNamed() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), helloWorld) {
+		t.Errorf("Encoder.EncodeNamed() = %v, want %v", buf.Bytes(), helloWorld)
+	}
+}
@@ -0,0 +1,64 @@
+package nbt
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+)
+
+// Compression selects how EncodeFile compresses its output.
+type Compression int
+
+const (
+	CompressionNone Compression = iota
+	CompressionGzip
+	CompressionZlib
+)
+
+// EncodeFile writes v in the file format with a root tag called name, as
+// found in level.dat and structure files.
+func EncodeFile(w io.Writer, name string, v any, c Compression) error {
+	var wc io.WriteCloser
+	switch c {
+	case CompressionGzip:
+		wc = gzip.NewWriter(w)
+	case CompressionZlib:
+		wc = zlib.NewWriter(w)
+	default:
+		return NewEncoder(w).EncodeNamed(name, v)
+	}
+	if err := NewEncoder(wc).EncodeNamed(name, v); err != nil {
+		return err
+	}
+	return wc.Close()
+}
+
+// DecodeFile reads a file format tag into v and returns the name of its
+// root tag. Gzip and zlib compression are detected from the first bytes.
+func DecodeFile(r io.Reader, v any) (string, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+
+	var src io.Reader = br
+	switch {
+	case header[0] == 0x1F && header[1] == 0x8B:
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return "", err
+		}
+		defer zr.Close()
+		src = zr
+	case header[0] == 0x78:
+		zr, err := zlib.NewReader(br)
+		if err != nil {
+			return "", err
+		}
+		defer zr.Close()
+		src = zr
+	}
+	return NewDecoder(src).DecodeNamed(v)
+}
@@ -0,0 +1,71 @@
+package nbt_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+)
+
+// helloWorld is hello_world.nbt from the original NBT specification.
+var helloWorld = []byte{
+	0x0A, 0x00, 0x0B, 'h', 'e', 'l', 'l', 'o', ' ', 'w', 'o', 'r', 'l', 'd',
+	0x08, 0x00, 0x04, 'n', 'a', 'm', 'e', 0x00, 0x09, 'B', 'a', 'n', 'a', 'n', 'r', 'a', 'm', 'a',
+	0x00,
+}
+
+func TestEncodeFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression nbt.Compression
+		wantPrefix  []byte
+	}{
+		{name: "Uncompressed", compression: nbt.CompressionNone, wantPrefix: helloWorld},
+		{name: "Gzip", compression: nbt.CompressionGzip, wantPrefix: []byte{0x1F, 0x8B}},
+		{name: "Zlib", compression: nbt.CompressionZlib, wantPrefix: []byte{0x78}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			v := map[string]string{"name": "Bananrama"}
+			if err := nbt.EncodeFile(&buf, "hello world", v, tt.compression); err != nil {
+				t.Fatalf("EncodeFile() error = %v", err)
+			}
+			if !bytes.HasPrefix(buf.Bytes(), tt.wantPrefix) {
+				t.Errorf("EncodeFile() = %v, want prefix %v", buf.Bytes(), tt.wantPrefix)
+			}
+
+			var got map[string]string
+			name, err := nbt.DecodeFile(&buf, &got)
+			if err != nil {
+				t.Fatalf("DecodeFile() error = %v", err)
+			}
+			if name != "hello world" {
+				t.Errorf("DecodeFile() name = %v, want hello world", name)
+			}
+			if !reflect.DeepEqual(got, v) {
+				t.Errorf("DecodeFile() v = %v, want %v", got, v)
+			}
+		})
+	}
+}
+
+func TestDecodeFile_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Empty", data: nil},
+		{name: "Truncated", data: helloWorld[:20]},
+		{name: "Corrupt gzip", data: []byte{0x1F, 0x8B, 0x00}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			if _, err := nbt.DecodeFile(bytes.NewReader(tt.data), &v); err == nil {
+				t.Errorf("DecodeFile() error = nil, want error")
+			}
+		})
+	}
+}
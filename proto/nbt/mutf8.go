@@ -0,0 +1,58 @@
+package nbt
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// appendString appends s in Java's modified UTF-8, prefixed with its
+// length: NUL takes two bytes and supplementary characters are written as
+// a surrogate pair of three-byte sequences.
+func appendString(b []byte, s string) ([]byte, error) {
+	start := len(b)
+	b = append(b, 0, 0)
+	for _, r := range s {
+		switch {
+		case r != 0 && r < 0x80:
+			b = append(b, byte(r))
+		case r < 0x800:
+			b = append(b, 0xC0|byte(r>>6), 0x80|byte(r&0x3F))
+		case r < 0x10000:
+			b = appendUnit(b, uint16(r))
+		default:
+			r1, r2 := utf16.EncodeRune(r)
+			b = appendUnit(appendUnit(b, uint16(r1)), uint16(r2))
+		}
+	}
+	n := len(b) - start - 2
+	if n > 0xFFFF {
+		return nil, ErrStringTooLong
+	}
+	binary.BigEndian.PutUint16(b[start:], uint16(n))
+	return b, nil
+}
+
+func appendUnit(b []byte, u uint16) []byte {
+	return append(b, 0xE0|byte(u>>12), 0x80|byte(u>>6&0x3F), 0x80|byte(u&0x3F))
+}
+
+func decodeString(b []byte) (string, error) {
+	units := make([]uint16, 0, len(b))
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c < 0x80:
+			units = append(units, uint16(c))
+			i++
+		case c&0xE0 == 0xC0 && i+1 < len(b) && b[i+1]&0xC0 == 0x80:
+			units = append(units, uint16(c&0x1F)<<6|uint16(b[i+1]&0x3F))
+			i += 2
+		case c&0xF0 == 0xE0 && i+2 < len(b) && b[i+1]&0xC0 == 0x80 && b[i+2]&0xC0 == 0x80:
+			units = append(units, uint16(c&0x0F)<<12|uint16(b[i+1]&0x3F)<<6|uint16(b[i+2]&0x3F))
+			i += 3
+		default:
+			return "", ErrInvalidString
+		}
+	}
+	return string(utf16.Decode(units)), nil
+}
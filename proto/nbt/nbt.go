@@ -0,0 +1,166 @@
+// Package nbt implements the Named Binary Tag format used by Minecraft for
+// structured data, in both its network and file variants, and the
+// stringified SNBT form.
+//
+// Go values map to tags as follows:
+//
+//	bool, int8, uint8        Byte
+//	int16, uint16            Short
+//	int, int32, uint, uint32 Int
+//	int64, uint64            Long
+//	float32                  Float
+//	float64                  Double
+//	string                   String
+//	[]byte, []int8           Byte Array
+//	[]int32, []uint32        Int Array
+//	[]int64, []uint64        Long Array
+//	other slices and arrays  List
+//	structs, map[string]T    Compound
+//
+// Struct fields are named by an `nbt:"name"` tag, falling back to the field
+// name. The "omitempty" option skips zero values, the "list" option encodes
+// a numeric slice as a List instead of an array, and a name of "-" skips
+// the field. Decoding into an interface value produces the same Go types,
+// with Lists as []any and Compounds as map[string]any.
+package nbt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type TagType byte
+
+const (
+	TagEnd TagType = iota
+	TagByte
+	TagShort
+	TagInt
+	TagLong
+	TagFloat
+	TagDouble
+	TagByteArray
+	TagString
+	TagList
+	TagCompound
+	TagIntArray
+	TagLongArray
+)
+
+func (t TagType) String() string {
+	switch t {
+	case TagEnd:
+		return "TAG_End"
+	case TagByte:
+		return "TAG_Byte"
+	case TagShort:
+		return "TAG_Short"
+	case TagInt:
+		return "TAG_Int"
+	case TagLong:
+		return "TAG_Long"
+	case TagFloat:
+		return "TAG_Float"
+	case TagDouble:
+		return "TAG_Double"
+	case TagByteArray:
+		return "TAG_Byte_Array"
+	case TagString:
+		return "TAG_String"
+	case TagList:
+		return "TAG_List"
+	case TagCompound:
+		return "TAG_Compound"
+	case TagIntArray:
+		return "TAG_Int_Array"
+	case TagLongArray:
+		return "TAG_Long_Array"
+	default:
+		return fmt.Sprintf("TagType(%d)", byte(t))
+	}
+}
+
+// MaxDepth is the deepest nesting of Lists and Compounds accepted when
+// decoding, matching vanilla.
+const MaxDepth = 512
+
+var (
+	ErrInvalidTagType = errors.New("nbt: invalid tag type")
+	ErrInvalidLength  = errors.New("nbt: invalid length")
+	ErrMaxDepth       = errors.New("nbt: maximum depth exceeded")
+	ErrMixedList      = errors.New("nbt: list elements have different types")
+	ErrInvalidString  = errors.New("nbt: invalid modified UTF-8 string")
+	ErrStringTooLong  = errors.New("nbt: string is too long")
+)
+
+// Marshaler is implemented by types that encode themselves by returning
+// another value to encode in their place.
+type Marshaler interface {
+	MarshalNBT() (any, error)
+}
+
+// Unmarshaler is implemented by types that decode themselves from the
+// generic form of a tag, as produced when decoding into an interface value.
+type Unmarshaler interface {
+	UnmarshalNBT(v any) error
+}
+
+// UnsupportedTypeError is returned when encoding a Go type with no tag
+// equivalent.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "nbt: unsupported type " + e.Type.String()
+}
+
+// UnmarshalTypeError is returned when a tag cannot be stored in a Go value
+// of the given type.
+type UnmarshalTypeError struct {
+	Tag  TagType
+	Type reflect.Type
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return "nbt: cannot unmarshal " + e.Tag.String() + " into Go value of type " + e.Type.String()
+}
+
+// tag is the decoded form shared by the binary and SNBT codecs. Its value
+// is an int8, int16, int32, int64, float32, float64, []byte, string, list,
+// []field, []int32 or []int64 depending on typ.
+type tag struct {
+	typ TagType
+	v   any
+}
+
+type list struct {
+	elem  TagType
+	items []tag
+}
+
+type field struct {
+	name string
+	tag  tag
+}
+
+// generic converts t to the values described in the package comment.
+func (t tag) generic() any {
+	switch v := t.v.(type) {
+	case list:
+		items := make([]any, len(v.items))
+		for i, item := range v.items {
+			items[i] = item.generic()
+		}
+		return items
+	case []field:
+		m := make(map[string]any, len(v))
+		for _, f := range v {
+			m[f.name] = f.tag.generic()
+		}
+		return m
+	default:
+		return v
+	}
+}
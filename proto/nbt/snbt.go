@@ -0,0 +1,415 @@
+package nbt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SyntaxError describes malformed SNBT.
+type SyntaxError struct {
+	Offset int
+	msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("nbt: %s at offset %d", e.msg, e.Offset)
+}
+
+// MarshalSNBT returns the compact stringified form of v, such as
+// {name:"Bananrama",scores:[I;1,2,3]}.
+func MarshalSNBT(v any) (string, error) {
+	t, err := encodeValue(reflect.ValueOf(v), false)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	writeSNBT(&sb, t)
+	return sb.String(), nil
+}
+
+func writeSNBT(sb *strings.Builder, t tag) {
+	switch v := t.v.(type) {
+	case int8:
+		sb.WriteString(strconv.FormatInt(int64(v), 10) + "b")
+	case int16:
+		sb.WriteString(strconv.FormatInt(int64(v), 10) + "s")
+	case int32:
+		sb.WriteString(strconv.FormatInt(int64(v), 10))
+	case int64:
+		sb.WriteString(strconv.FormatInt(v, 10) + "L")
+	case float32:
+		sb.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32) + "f")
+	case float64:
+		sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64) + "d")
+	case string:
+		sb.WriteString(quote(v))
+	case []byte:
+		sb.WriteString("[B;")
+		for i, b := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.FormatInt(int64(int8(b)), 10) + "b")
+		}
+		sb.WriteByte(']')
+	case []int32:
+		sb.WriteString("[I;")
+		for i, n := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+		}
+		sb.WriteByte(']')
+	case []int64:
+		sb.WriteString("[L;")
+		for i, n := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.FormatInt(n, 10) + "L")
+		}
+		sb.WriteByte(']')
+	case list:
+		sb.WriteByte('[')
+		for i, item := range v.items {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeSNBT(sb, item)
+		}
+		sb.WriteByte(']')
+	case []field:
+		sb.WriteByte('{')
+		for i, f := range v {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			if isBare(f.name) {
+				sb.WriteString(f.name)
+			} else {
+				sb.WriteString(quote(f.name))
+			}
+			sb.WriteByte(':')
+			writeSNBT(sb, f.tag)
+		}
+		sb.WriteByte('}')
+	}
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func isBare(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := range len(s) {
+		if !isBareByte(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isBareByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+		c == '_' || c == '-' || c == '.' || c == '+'
+}
+
+// UnmarshalSNBT parses s and stores the result in v, which must be a
+// non-nil pointer. Unquoted values are typed by their suffix as in
+// vanilla: 1b, 1s, 1, 1L, 1.5f and 1.5 or 1.5d, with true and false as
+// bytes.
+func UnmarshalSNBT(s string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("nbt: UnmarshalSNBT requires a non-nil pointer")
+	}
+	p := &parser{s: s}
+	t, err := p.value(0)
+	if err != nil {
+		return err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return p.errorf("unexpected %q after value", p.s[p.pos])
+	}
+	return assign(t, rv)
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Offset: p.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume skips spaces and then c, reporting whether c was found.
+func (p *parser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) value(depth int) (tag, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return tag{}, p.errorf("unexpected end of input")
+	}
+	switch c := p.s[p.pos]; {
+	case c == '{':
+		if depth >= MaxDepth {
+			return tag{}, ErrMaxDepth
+		}
+		return p.compound(depth)
+	case c == '[':
+		if depth >= MaxDepth {
+			return tag{}, ErrMaxDepth
+		}
+		return p.list(depth)
+	case c == '"' || c == '\'':
+		s, err := p.quoted()
+		return tag{TagString, s}, err
+	default:
+		token := p.bare()
+		if token == "" {
+			return tag{}, p.errorf("unexpected %q", c)
+		}
+		return scalar(token), nil
+	}
+}
+
+func (p *parser) compound(depth int) (tag, error) {
+	p.pos++
+	fields := []field{}
+	if p.consume('}') {
+		return tag{TagCompound, fields}, nil
+	}
+	for {
+		p.skipSpace()
+		var name string
+		if p.pos < len(p.s) && (p.s[p.pos] == '"' || p.s[p.pos] == '\'') {
+			var err error
+			if name, err = p.quoted(); err != nil {
+				return tag{}, err
+			}
+		} else if name = p.bare(); name == "" {
+			return tag{}, p.errorf("expected key")
+		}
+		if !p.consume(':') {
+			return tag{}, p.errorf("expected ':'")
+		}
+		t, err := p.value(depth + 1)
+		if err != nil {
+			return tag{}, err
+		}
+		fields = append(fields, field{name, t})
+
+		if p.consume(',') {
+			continue
+		}
+		if p.consume('}') {
+			return tag{TagCompound, fields}, nil
+		}
+		return tag{}, p.errorf("expected ',' or '}'")
+	}
+}
+
+func (p *parser) list(depth int) (tag, error) {
+	p.pos++
+	if p.pos+1 < len(p.s) && p.s[p.pos+1] == ';' {
+		switch p.s[p.pos] {
+		case 'B':
+			return p.array(TagByte)
+		case 'I':
+			return p.array(TagInt)
+		case 'L':
+			return p.array(TagLong)
+		}
+	}
+
+	l := list{elem: TagEnd}
+	if p.consume(']') {
+		return tag{TagList, l}, nil
+	}
+	for {
+		start := p.pos
+		item, err := p.value(depth + 1)
+		if err != nil {
+			return tag{}, err
+		}
+		if len(l.items) == 0 {
+			l.elem = item.typ
+		} else if item.typ != l.elem {
+			p.pos = start
+			return tag{}, p.errorf("%s in list of %s", item.typ, l.elem)
+		}
+		l.items = append(l.items, item)
+
+		if p.consume(',') {
+			continue
+		}
+		if p.consume(']') {
+			return tag{TagList, l}, nil
+		}
+		return tag{}, p.errorf("expected ',' or ']'")
+	}
+}
+
+func (p *parser) array(elem TagType) (tag, error) {
+	p.pos += 2
+	var items []tag
+	if !p.consume(']') {
+		for {
+			start := p.pos
+			item, err := p.value(MaxDepth)
+			if err != nil {
+				return tag{}, err
+			}
+			if item.typ != elem {
+				p.pos = start
+				return tag{}, p.errorf("%s in array of %s", item.typ, elem)
+			}
+			items = append(items, item)
+
+			if p.consume(',') {
+				continue
+			}
+			if p.consume(']') {
+				break
+			}
+			return tag{}, p.errorf("expected ',' or ']'")
+		}
+	}
+
+	switch elem {
+	case TagByte:
+		b := make([]byte, len(items))
+		for i, item := range items {
+			b[i] = byte(item.v.(int8))
+		}
+		return tag{TagByteArray, b}, nil
+	case TagInt:
+		a := make([]int32, len(items))
+		for i, item := range items {
+			a[i] = item.v.(int32)
+		}
+		return tag{TagIntArray, a}, nil
+	default:
+		a := make([]int64, len(items))
+		for i, item := range items {
+			a[i] = item.v.(int64)
+		}
+		return tag{TagLongArray, a}, nil
+	}
+}
+
+func (p *parser) quoted() (string, error) {
+	q := p.s[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case q:
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.s) || p.s[p.pos] != q && p.s[p.pos] != '\\' {
+				return "", p.errorf("invalid escape")
+			}
+			sb.WriteByte(p.s[p.pos])
+			p.pos++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) bare() string {
+	start := p.pos
+	for p.pos < len(p.s) && isBareByte(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// scalar types an unquoted token, falling back to a string when it is not
+// a number in range.
+func scalar(token string) tag {
+	switch token {
+	case "true":
+		return tag{TagByte, int8(1)}
+	case "false":
+		return tag{TagByte, int8(0)}
+	}
+
+	body, suffix := token[:len(token)-1], token[len(token)-1]
+	if numeric(body) {
+		switch suffix {
+		case 'b', 'B':
+			if i, err := strconv.ParseInt(body, 10, 8); err == nil {
+				return tag{TagByte, int8(i)}
+			}
+		case 's', 'S':
+			if i, err := strconv.ParseInt(body, 10, 16); err == nil {
+				return tag{TagShort, int16(i)}
+			}
+		case 'l', 'L':
+			if i, err := strconv.ParseInt(body, 10, 64); err == nil {
+				return tag{TagLong, i}
+			}
+		case 'f', 'F':
+			if f, err := strconv.ParseFloat(body, 32); err == nil {
+				return tag{TagFloat, float32(f)}
+			}
+		case 'd', 'D':
+			if f, err := strconv.ParseFloat(body, 64); err == nil {
+				return tag{TagDouble, f}
+			}
+		}
+	}
+	if numeric(token) {
+		if !strings.ContainsAny(token, ".eE") {
+			if i, err := strconv.ParseInt(token, 10, 32); err == nil {
+				return tag{TagInt, int32(i)}
+			}
+		} else if f, err := strconv.ParseFloat(token, 64); err == nil {
+			return tag{TagDouble, f}
+		}
+	}
+	return tag{TagString, token}
+}
+
+// numeric reports whether s looks like a decimal number, so that words
+// such as "inf" stay strings.
+func numeric(s string) bool {
+	digits := false
+	for i := range len(s) {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits = true
+		case c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-':
+		default:
+			return false
+		}
+	}
+	return digits
+}
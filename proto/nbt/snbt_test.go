@@ -0,0 +1,126 @@
+package nbt_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+)
+
+func TestMarshalSNBT(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		want    string
+		wantErr bool
+	}{
+		{name: "Byte", v: int8(-1), want: "-1b"},
+		{name: "Short", v: int16(300), want: "300s"},
+		{name: "Int", v: int32(7), want: "7"},
+		{name: "Long", v: int64(1), want: "1L"},
+		{name: "Float", v: float32(0.5), want: "0.5f"},
+		{name: "Double", v: 1.0, want: "1d"},
+		{name: "String", v: `say "hi" \o/`, want: `"say \"hi\" \\o/"`},
+		{name: "Byte array", v: []byte{1, 0xFF}, want: "[B;1b,-1b]"},
+		{name: "Int array", v: []int32{1, 2}, want: "[I;1,2]"},
+		{name: "Long array", v: []int64{3}, want: "[L;3L]"},
+		{name: "List", v: []string{"a", "b"}, want: `["a","b"]`},
+		{name: "Compound", v: map[string]any{"name": "Bananrama", "minecraft:id": int8(1)}, want: `{"minecraft:id":1b,name:"Bananrama"}`},
+		{name: "Struct", v: testPos{X: 1, Y: 2, Z: 3}, want: "{X:1,Y:2,Z:3}"},
+		{name: "Mixed list", v: []any{1, "a"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nbt.MarshalSNBT(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MarshalSNBT() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MarshalSNBT() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSNBT(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    any
+		wantErr bool
+	}{
+		{name: "Byte", s: "1b", want: int8(1)},
+		{name: "Boolean", s: "true", want: int8(1)},
+		{name: "Short", s: "-2S", want: int16(-2)},
+		{name: "Int", s: "42", want: int32(42)},
+		{name: "Long", s: "5000000000L", want: int64(5000000000)},
+		{name: "Float", s: "1.5f", want: float32(1.5)},
+		{name: "Double without suffix", s: "1.5", want: 1.5},
+		{name: "Double with exponent", s: "1e3d", want: 1000.0},
+		{name: "Unquoted string", s: "stone", want: "stone"},
+		{name: "Out of range int is a string", s: "3000000000", want: "3000000000"},
+		{name: "Double-quoted string", s: `"a \"b\" \\c"`, want: `a "b" \c`},
+		{name: "Unbalanced quotes", s: `'it''`, wantErr: true},
+		{name: "Single-quoted string with escape", s: `'it\'s'`, want: "it's"},
+		{name: "Byte array", s: "[B; 1b, -1b]", want: []byte{1, 0xFF}},
+		{name: "Int array", s: "[I;1,2]", want: []int32{1, 2}},
+		{name: "Long array", s: "[L;]", want: []int64{}},
+		{name: "List", s: "[ 1 , 2 ]", want: []any{int32(1), int32(2)}},
+		{name: "Empty list", s: "[]", want: []any{}},
+		{
+			name: "Compound",
+			s:    ` { name : "Bananrama", "minecraft:id": 1b, nested: {list: [{}]} } `,
+			want: map[string]any{"name": "Bananrama", "minecraft:id": int8(1), "nested": map[string]any{"list": []any{map[string]any{}}}},
+		},
+		{name: "Mixed list", s: "[1, 1b]", wantErr: true},
+		{name: "Wrong array element", s: "[I;1L]", wantErr: true},
+		{name: "Missing colon", s: "{a 1}", wantErr: true},
+		{name: "Unterminated compound", s: "{a:1", wantErr: true},
+		{name: "Unterminated string", s: `"abc`, wantErr: true},
+		{name: "Trailing characters", s: "1 2", wantErr: true},
+		{name: "Empty input", s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got any
+			err := nbt.UnmarshalSNBT(tt.s, &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalSNBT() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalSNBT() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSNBT_struct(t *testing.T) {
+	var got testPlayer
+	if err := nbt.UnmarshalSNBT(`{name:"Notch",health:20f,level:3b,scores:[1,2]}`, &got); err != nil {
+		t.Fatalf("UnmarshalSNBT() error = %v", err)
+	}
+	want := testPlayer{Name: "Notch", Health: 20, Level: 3, Scores: []int32{1, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnmarshalSNBT() = %+v, want %+v", got, want)
+	}
+
+	s, err := nbt.MarshalSNBT(got)
+	if err != nil {
+		t.Fatalf("MarshalSNBT() error = %v", err)
+	}
+	if want := `{name:"Notch",health:20f,level:3,scores:[1,2]}`; s != want {
+		t.Errorf("MarshalSNBT() = %v, want %v", s, want)
+	}
+}
+
+func TestUnmarshalSNBT_syntaxError(t *testing.T) {
+	var v any
+	err := nbt.UnmarshalSNBT("{a:1,}", &v)
+	var syntaxErr *nbt.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 5 {
+		t.Errorf("UnmarshalSNBT() error = %v, want SyntaxError at offset 5", err)
+	}
+}
@@ -3,26 +3,52 @@ package proto
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 
+	"github.com/nonya123456/cobble/proto/stream"
 	"github.com/nonya123456/cobble/proto/types"
 )
 
+// DefaultMaxPacketLength is the largest frame length vanilla accepts, the
+// most a 3-byte VarInt prefix can hold.
+const DefaultMaxPacketLength = 1<<21 - 1
+
 var (
 	ErrInvalidPacketLength = errors.New("invalid packet length")
 )
 
+// PacketTooLargeError is returned when a frame's length prefix exceeds the
+// maximum packet length. Addr is set when the frame was read from a Conn.
+type PacketTooLargeError struct {
+	Length int
+	Addr   net.Addr
+}
+
+func (e *PacketTooLargeError) Error() string {
+	if e.Addr == nil {
+		return fmt.Sprintf("packet length %d exceeds maximum", e.Length)
+	}
+	return fmt.Sprintf("packet length %d from %s exceeds maximum", e.Length, e.Addr)
+}
+
 type Packet struct {
 	ID   int32
 	Data []byte
 }
 
 func ReadPacket(r io.Reader) (Packet, error) {
-	var lengthProto types.VarInt
-	if _, err := lengthProto.ReadFrom(r); err != nil {
+	return ReadPacketLimit(r, DefaultMaxPacketLength)
+}
+
+// ReadPacketLimit is like ReadPacket but rejects frames longer than
+// maxLength bytes.
+func ReadPacketLimit(r io.Reader, maxLength int) (Packet, error) {
+	length, err := readPacketLength(r, maxLength)
+	if err != nil {
 		return Packet{}, err
 	}
-	length := int(lengthProto)
 
 	var id types.VarInt
 	idLength, err := id.ReadFrom(r)
@@ -35,8 +61,8 @@ func ReadPacket(r io.Reader) (Packet, error) {
 		return Packet{}, ErrInvalidPacketLength
 	}
 
-	data := make([]byte, dataLength)
-	if _, err := io.ReadFull(r, data); err != nil {
+	data, err := stream.ReadBytes(r, dataLength)
+	if err != nil {
 		return Packet{}, err
 	}
 
@@ -68,3 +94,17 @@ func WritePacket(w io.Writer, id int32, p io.WriterTo) error {
 
 	return nil
 }
+
+func readPacketLength(r io.Reader, maxLength int) (int, error) {
+	var length types.VarInt
+	if _, err := length.ReadFrom(r); err != nil {
+		return 0, err
+	}
+	if length < 0 {
+		return 0, ErrInvalidPacketLength
+	}
+	if int(length) > maxLength {
+		return 0, &PacketTooLargeError{Length: int(length)}
+	}
+	return int(length), nil
+}
@@ -25,6 +25,24 @@ func TestReadPacket(t *testing.T) {
 			want:    proto.Packet{ID: 1, Data: []byte("Hello, Packet!")},
 			wantErr: false,
 		},
+		{
+			name:    "Negative length",
+			args:    args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F, 0x01})},
+			want:    proto.Packet{},
+			wantErr: true,
+		},
+		{
+			name:    "Length over maximum",
+			args:    args{bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x01, 0x01})},
+			want:    proto.Packet{},
+			wantErr: true,
+		},
+		{
+			name:    "Truncated packet",
+			args:    args{bytes.NewReader(append([]byte{0xFF, 0xFF, 0x7F, 0x01}, []byte("Hello, Packet!")...))},
+			want:    proto.Packet{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -74,3 +92,23 @@ func TestWritePacket(t *testing.T) {
 		})
 	}
 }
+
+func TestReadPacketLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		frame     []byte
+		maxLength int
+		wantErr   error
+	}{
+		{name: "At maximum", frame: []byte{0x03, 0x01, 0xAA, 0xBB}, maxLength: 3},
+		{name: "Over maximum", frame: []byte{0x04, 0x01, 0xAA, 0xBB, 0xCC}, maxLength: 3, wantErr: &proto.PacketTooLargeError{Length: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := proto.ReadPacketLimit(bytes.NewReader(tt.frame), tt.maxLength)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("ReadPacketLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
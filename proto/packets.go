@@ -0,0 +1,70 @@
+package proto
+
+import (
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/play"
+	"github.com/nonya123456/cobble/proto/status"
+)
+
+// DefaultRegistry holds the packets implemented by this module.
+var DefaultRegistry = NewRegistry()
+
+type registration struct {
+	state      State
+	direction  Direction
+	id         int32
+	newMessage func() Message
+}
+
+var defaultPackets = []registration{
+	{StateHandshaking, Serverbound, handshaking.HandshakeID, func() Message { return new(handshaking.Handshake) }},
+
+	{StateStatus, Serverbound, status.StatusRequestID, func() Message { return new(status.StatusRequest) }},
+	{StateStatus, Serverbound, status.PingRequestID, func() Message { return new(status.PingRequest) }},
+	{StateStatus, Clientbound, status.StatusResponseID, func() Message { return new(status.StatusResponse) }},
+	{StateStatus, Clientbound, status.PingResponseID, func() Message { return new(status.PingResponse) }},
+
+	{StateLogin, Serverbound, login.LoginStartID, func() Message { return new(login.LoginStart) }},
+	{StateLogin, Serverbound, login.EncryptionResponseID, func() Message { return new(login.EncryptionResponse) }},
+	{StateLogin, Serverbound, login.LoginAcknowledgedID, func() Message { return new(login.LoginAcknowledged) }},
+	{StateLogin, Clientbound, login.DisconnectID, func() Message { return new(login.Disconnect) }},
+	{StateLogin, Clientbound, login.EncryptionRequestID, func() Message { return new(login.EncryptionRequest) }},
+	{StateLogin, Clientbound, login.LoginSuccessID, func() Message { return new(login.LoginSuccess) }},
+	{StateLogin, Clientbound, login.SetCompressionID, func() Message { return new(login.SetCompression) }},
+
+	{StateConfiguration, Serverbound, configuration.ClientInformationID, func() Message { return new(configuration.ClientInformation) }},
+	{StateConfiguration, Serverbound, configuration.ServerboundPluginMessageID, func() Message { return new(configuration.ServerboundPluginMessage) }},
+	{StateConfiguration, Serverbound, configuration.AcknowledgeFinishConfigurationID, func() Message { return new(configuration.AcknowledgeFinishConfiguration) }},
+	{StateConfiguration, Serverbound, configuration.ServerboundKeepAliveID, func() Message { return new(configuration.ServerboundKeepAlive) }},
+	{StateConfiguration, Serverbound, configuration.ServerboundKnownPacksID, func() Message { return new(configuration.ServerboundKnownPacks) }},
+	{StateConfiguration, Clientbound, configuration.ClientboundPluginMessageID, func() Message { return new(configuration.ClientboundPluginMessage) }},
+	{StateConfiguration, Clientbound, configuration.DisconnectID, func() Message { return new(configuration.Disconnect) }},
+	{StateConfiguration, Clientbound, configuration.FinishConfigurationID, func() Message { return new(configuration.FinishConfiguration) }},
+	{StateConfiguration, Clientbound, configuration.ClientboundKeepAliveID, func() Message { return new(configuration.ClientboundKeepAlive) }},
+	{StateConfiguration, Clientbound, configuration.RegistryDataID, func() Message { return new(configuration.RegistryData) }},
+	{StateConfiguration, Clientbound, configuration.FeatureFlagsID, func() Message { return new(configuration.FeatureFlags) }},
+	{StateConfiguration, Clientbound, configuration.UpdateTagsID, func() Message { return new(configuration.UpdateTags) }},
+	{StateConfiguration, Clientbound, configuration.ClientboundKnownPacksID, func() Message { return new(configuration.ClientboundKnownPacks) }},
+
+	{StatePlay, Serverbound, play.ConfirmTeleportationID, func() Message { return new(play.ConfirmTeleportation) }},
+	{StatePlay, Serverbound, play.ServerboundKeepAliveID, func() Message { return new(play.ServerboundKeepAlive) }},
+	{StatePlay, Clientbound, play.DisconnectID, func() Message { return new(play.Disconnect) }},
+	{StatePlay, Clientbound, play.GameEventID, func() Message { return new(play.GameEvent) }},
+	{StatePlay, Clientbound, play.ClientboundKeepAliveID, func() Message { return new(play.ClientboundKeepAlive) }},
+	{StatePlay, Clientbound, play.ChunkDataAndUpdateLightID, func() Message { return new(play.ChunkDataAndUpdateLight) }},
+	{StatePlay, Clientbound, play.LoginID, func() Message { return new(play.Login) }},
+	{StatePlay, Clientbound, play.SynchronizePlayerPositionID, func() Message { return new(play.SynchronizePlayerPosition) }},
+	{StatePlay, Clientbound, play.SetCenterChunkID, func() Message { return new(play.SetCenterChunk) }},
+}
+
+// The packets above have the same IDs in every supported version. A
+// version whose IDs differ registers its own table.
+func init() {
+	for _, v := range Versions {
+		for _, p := range defaultPackets {
+			DefaultRegistry.Register(v.Protocol, p.state, p.direction, p.id, p.newMessage)
+		}
+	}
+}
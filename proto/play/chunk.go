@@ -0,0 +1,28 @@
+package play
+
+import (
+	"bytes"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+// EmptyChunkSections encodes sections of air for the Data field of
+// ChunkDataAndUpdateLight, with every cell set to the biome of the given
+// network ID.
+func EmptyChunkSections(sections int, biome int32) []byte {
+	var section bytes.Buffer
+	blockCount := types.Short(0)
+	blockCount.WriteTo(&section)
+	writeSingleValue(&section, 0)
+	writeSingleValue(&section, biome)
+	return bytes.Repeat(section.Bytes(), sections)
+}
+
+// writeSingleValue writes a paletted container holding one value: zero
+// bits per entry, the value, and an empty data array.
+func writeSingleValue(buf *bytes.Buffer, value int32) {
+	buf.WriteByte(0)
+	v := types.VarInt(value)
+	v.WriteTo(buf)
+	buf.WriteByte(0)
+}
@@ -0,0 +1,46 @@
+package play_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/play"
+)
+
+func TestEmptyChunkSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections int
+		biome    int32
+		want     []byte
+	}{
+		{
+			name:     "No sections",
+			sections: 0,
+			biome:    0,
+			want:     []byte{},
+		},
+		{
+			name:     "One section",
+			sections: 1,
+			biome:    3,
+			want:     []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00},
+		},
+		{
+			name:     "Two sections with a large biome ID",
+			sections: 2,
+			biome:    200,
+			want: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC8, 0x01, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC8, 0x01, 0x00,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := play.EmptyChunkSections(tt.sections, tt.biome); !bytes.Equal(got, tt.want) {
+				t.Errorf("EmptyChunkSections() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package play
+
+const (
+	GameModeSurvival uint8 = iota
+	GameModeCreative
+	GameModeAdventure
+	GameModeSpectator
+)
+
+// GameEventStartWaitingForChunks tells the client to leave the loading
+// screen once the chunk it stands in has arrived.
+const GameEventStartWaitingForChunks uint8 = 13
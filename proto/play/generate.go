@@ -0,0 +1,3 @@
+package play
+
+//go:generate go run github.com/nonya123456/cobble/cmd/packetgen -in packets.json
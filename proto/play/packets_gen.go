@@ -0,0 +1,192 @@
+// Code generated by packetgen from packets.json. DO NOT EDIT.
+
+package play
+
+import (
+	"io"
+
+	"github.com/nonya123456/cobble/proto/codec"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+const (
+	ConfirmTeleportationID      = 0x00
+	ServerboundKeepAliveID      = 0x1A
+	DisconnectID                = 0x1D
+	GameEventID                 = 0x23
+	ClientboundKeepAliveID      = 0x27
+	ChunkDataAndUpdateLightID   = 0x28
+	LoginID                     = 0x2C
+	SynchronizePlayerPositionID = 0x42
+	SetCenterChunkID            = 0x58
+)
+
+type DeathLocation struct {
+	Dimension types.Identifier
+	Location  types.Position
+}
+
+type BlockEntity struct {
+	PackedXZ uint8
+	Y        int16
+	Type     int32 `mc:"varint"`
+	Data     any   `mc:"nbt"`
+}
+
+// ConfirmTeleportation is the serverbound play packet 0x00.
+type ConfirmTeleportation struct {
+	TeleportID int32 `mc:"varint"`
+}
+
+func (c *ConfirmTeleportation) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ConfirmTeleportation) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
+
+// ServerboundKeepAlive is the serverbound play packet 0x1A.
+type ServerboundKeepAlive struct {
+	ID int64
+}
+
+func (s *ServerboundKeepAlive) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *ServerboundKeepAlive) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
+
+// Disconnect is the clientbound play packet 0x1D.
+type Disconnect struct {
+	Reason text.Component
+}
+
+func (d *Disconnect) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, d)
+}
+
+func (d *Disconnect) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, d)
+}
+
+// GameEvent is the clientbound play packet 0x23.
+type GameEvent struct {
+	Event uint8
+	Value float32
+}
+
+func (g *GameEvent) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, g)
+}
+
+func (g *GameEvent) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, g)
+}
+
+// ClientboundKeepAlive is the clientbound play packet 0x27.
+type ClientboundKeepAlive struct {
+	ID int64
+}
+
+func (c *ClientboundKeepAlive) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ClientboundKeepAlive) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
+
+// ChunkDataAndUpdateLight is the clientbound play packet 0x28.
+type ChunkDataAndUpdateLight struct {
+	ChunkX              int32
+	ChunkZ              int32
+	Heightmaps          map[string]any `mc:"nbt"`
+	Data                []byte
+	BlockEntities       []BlockEntity `mc:"array"`
+	SkyLightMask        []int64       `mc:"array"`
+	BlockLightMask      []int64       `mc:"array"`
+	EmptySkyLightMask   []int64       `mc:"array"`
+	EmptyBlockLightMask []int64       `mc:"array"`
+	SkyLight            [][]byte      `mc:"array"`
+	BlockLight          [][]byte      `mc:"array"`
+}
+
+func (c *ChunkDataAndUpdateLight) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, c)
+}
+
+func (c *ChunkDataAndUpdateLight) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, c)
+}
+
+// Login is the clientbound play packet 0x2C.
+type Login struct {
+	EntityID            int32
+	IsHardcore          bool
+	DimensionNames      []types.Identifier `mc:"array"`
+	MaxPlayers          int32              `mc:"varint"`
+	ViewDistance        int32              `mc:"varint"`
+	SimulationDistance  int32              `mc:"varint"`
+	ReducedDebugInfo    bool
+	EnableRespawnScreen bool
+	DoLimitedCrafting   bool
+	DimensionType       int32 `mc:"varint"`
+	DimensionName       types.Identifier
+	HashedSeed          int64
+	GameMode            uint8
+	PreviousGameMode    int8
+	IsDebug             bool
+	IsFlat              bool
+	DeathLocation       *DeathLocation `mc:"optional"`
+	PortalCooldown      int32          `mc:"varint"`
+	SeaLevel            int32          `mc:"varint"`
+	EnforcesSecureChat  bool
+}
+
+func (l *Login) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, l)
+}
+
+func (l *Login) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, l)
+}
+
+// SynchronizePlayerPosition is the clientbound play packet 0x42.
+type SynchronizePlayerPosition struct {
+	TeleportID int32 `mc:"varint"`
+	X          float64
+	Y          float64
+	Z          float64
+	VelocityX  float64
+	VelocityY  float64
+	VelocityZ  float64
+	Yaw        float32
+	Pitch      float32
+	Flags      int32
+}
+
+func (s *SynchronizePlayerPosition) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *SynchronizePlayerPosition) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
+
+// SetCenterChunk is the clientbound play packet 0x58.
+type SetCenterChunk struct {
+	ChunkX int32 `mc:"varint"`
+	ChunkZ int32 `mc:"varint"`
+}
+
+func (s *SetCenterChunk) ReadFrom(r io.Reader) (int64, error) {
+	return codec.Read(r, s)
+}
+
+func (s *SetCenterChunk) WriteTo(w io.Writer) (int64, error) {
+	return codec.Write(w, s)
+}
@@ -0,0 +1,172 @@
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+type Direction int8
+
+const (
+	Serverbound Direction = iota
+	Clientbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Serverbound:
+		return "serverbound"
+	case Clientbound:
+		return "clientbound"
+	default:
+		return "Direction(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+// Message is the typed body of a packet.
+type Message interface {
+	io.ReaderFrom
+	io.WriterTo
+}
+
+// UnknownPacketError is returned when a registry has no packet for an ID.
+type UnknownPacketError struct {
+	Version   int32
+	State     State
+	Direction Direction
+	ID        int32
+}
+
+func (e *UnknownPacketError) Error() string {
+	return fmt.Sprintf("unknown %v packet %#02x in %v state of protocol %d", e.Direction, e.ID, e.State, e.Version)
+}
+
+// UnregisteredMessageError is returned when a registry has no ID for a
+// message type.
+type UnregisteredMessageError struct {
+	Version   int32
+	State     State
+	Direction Direction
+	Type      reflect.Type
+}
+
+func (e *UnregisteredMessageError) Error() string {
+	return fmt.Sprintf("%v is not a %v packet in %v state of protocol %d", e.Type, e.Direction, e.State, e.Version)
+}
+
+// TrailingDataError is returned by Registry.Decode when a packet holds more
+// data than its message reads.
+type TrailingDataError struct {
+	Type   reflect.Type
+	ID     int32
+	Length int
+}
+
+func (e *TrailingDataError) Error() string {
+	return fmt.Sprintf("packet %#02x (%v) has %d bytes of trailing data", e.ID, e.Type, e.Length)
+}
+
+type registryKey struct {
+	version   int32
+	state     State
+	direction Direction
+	id        int32
+}
+
+type messageKey struct {
+	version   int32
+	state     State
+	direction Direction
+	typ       reflect.Type
+}
+
+// Registry maps packet IDs to message types for each protocol version,
+// state and direction. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	messages map[registryKey]func() Message
+	ids      map[messageKey]int32
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		messages: make(map[registryKey]func() Message),
+		ids:      make(map[messageKey]int32),
+	}
+}
+
+// Register maps id to the message type returned by newMessage. It panics
+// if the ID or the type is already registered for the same version, state
+// and direction.
+func (r *Registry) Register(version int32, state State, direction Direction, id int32, newMessage func() Message) {
+	typ := reflect.TypeOf(newMessage())
+	key := registryKey{version, state, direction, id}
+	mkey := messageKey{version, state, direction, typ}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.messages[key]; ok {
+		panic(fmt.Sprintf("proto: %v packet %#02x registered twice in %v state of protocol %d", direction, id, state, version))
+	}
+	if _, ok := r.ids[mkey]; ok {
+		panic(fmt.Sprintf("proto: %v registered twice as %v packet in %v state of protocol %d", typ, direction, state, version))
+	}
+	r.messages[key] = newMessage
+	r.ids[mkey] = id
+}
+
+// New returns an empty message for the packet ID.
+func (r *Registry) New(version int32, state State, direction Direction, id int32) (Message, error) {
+	r.mu.RLock()
+	newMessage, ok := r.messages[registryKey{version, state, direction, id}]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, &UnknownPacketError{version, state, direction, id}
+	}
+	return newMessage(), nil
+}
+
+// ID returns the packet ID registered for the type of m.
+func (r *Registry) ID(version int32, state State, direction Direction, m Message) (int32, error) {
+	typ := reflect.TypeOf(m)
+	r.mu.RLock()
+	id, ok := r.ids[messageKey{version, state, direction, typ}]
+	r.mu.RUnlock()
+	if !ok {
+		return 0, &UnregisteredMessageError{version, state, direction, typ}
+	}
+	return id, nil
+}
+
+// Decode reads the typed message of p.
+func (r *Registry) Decode(version int32, state State, direction Direction, p Packet) (Message, error) {
+	m, err := r.New(version, state, direction, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	br := bytes.NewReader(p.Data)
+	if _, err := m.ReadFrom(br); err != nil {
+		return nil, err
+	}
+	if br.Len() > 0 {
+		return nil, &TrailingDataError{reflect.TypeOf(m), p.ID, br.Len()}
+	}
+	return m, nil
+}
+
+// Encode writes m into a packet with its registered ID.
+func (r *Registry) Encode(version int32, state State, direction Direction, m Message) (Packet, error) {
+	id, err := r.ID(version, state, direction, m)
+	if err != nil {
+		return Packet{}, err
+	}
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		return Packet{}, err
+	}
+	return Packet{ID: id, Data: buf.Bytes()}, nil
+}
@@ -0,0 +1,195 @@
+package proto_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/status"
+)
+
+func TestRegistry_Decode(t *testing.T) {
+	type args struct {
+		version   int32
+		state     proto.State
+		direction proto.Direction
+		p         proto.Packet
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        proto.Message
+		wantUnknown bool
+		wantErr     bool
+	}{
+		{
+			name: "Ping request",
+			args: args{768, proto.StateStatus, proto.Serverbound, proto.Packet{ID: 0x01, Data: []byte{0, 0, 0, 0, 0, 0, 0, 0x2A}}},
+			want: &status.PingRequest{Payload: 42},
+		},
+		{
+			name: "Ping response",
+			args: args{768, proto.StateStatus, proto.Clientbound, proto.Packet{ID: 0x01, Data: []byte{0, 0, 0, 0, 0, 0, 0, 0x2A}}},
+			want: &status.PingResponse{Payload: 42},
+		},
+		{
+			name: "Handshake",
+			args: args{768, proto.StateHandshaking, proto.Serverbound, proto.Packet{ID: 0x00, Data: []byte{0x80, 0x06, 0x00, 0x63, 0xDD, 0x02}}},
+			want: &handshaking.Handshake{ProtocolVersion: 768, ServerPort: 25565, NextState: 2},
+		},
+		{
+			name:        "Unknown ID",
+			args:        args{768, proto.StateStatus, proto.Serverbound, proto.Packet{ID: 0x05}},
+			wantUnknown: true,
+			wantErr:     true,
+		},
+		{
+			name:        "Wrong state",
+			args:        args{768, proto.StateLogin, proto.Serverbound, proto.Packet{ID: 0x01, Data: []byte{0, 0, 0, 0, 0, 0, 0, 0x2A}}},
+			want:        nil,
+			wantUnknown: false,
+			wantErr:     true,
+		},
+		{
+			name:        "Unknown version",
+			args:        args{47, proto.StateStatus, proto.Serverbound, proto.Packet{ID: 0x01, Data: []byte{0, 0, 0, 0, 0, 0, 0, 0x2A}}},
+			wantUnknown: true,
+			wantErr:     true,
+		},
+		{
+			name:    "Trailing data",
+			args:    args{768, proto.StateStatus, proto.Serverbound, proto.Packet{ID: 0x00, Data: []byte{0x01}}},
+			wantErr: true,
+		},
+		{
+			name:    "Truncated data",
+			args:    args{768, proto.StateStatus, proto.Serverbound, proto.Packet{ID: 0x01, Data: []byte{0, 0}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := proto.DefaultRegistry.Decode(tt.args.version, tt.args.state, tt.args.direction, tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Registry.Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var unknown *proto.UnknownPacketError
+			if errors.As(err, &unknown) != tt.wantUnknown {
+				t.Errorf("Registry.Decode() error = %v, wantUnknown %v", err, tt.wantUnknown)
+			}
+			if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Registry.Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Encode(t *testing.T) {
+	type args struct {
+		state     proto.State
+		direction proto.Direction
+		m         proto.Message
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    proto.Packet
+		wantErr bool
+	}{
+		{
+			name: "Set compression",
+			args: args{proto.StateLogin, proto.Clientbound, &login.SetCompression{Threshold: 256}},
+			want: proto.Packet{ID: 0x03, Data: []byte{0x80, 0x02}},
+		},
+		{
+			name: "Login acknowledged",
+			args: args{proto.StateLogin, proto.Serverbound, &login.LoginAcknowledged{}},
+			want: proto.Packet{ID: 0x03, Data: nil},
+		},
+		{
+			name:    "Wrong direction",
+			args:    args{proto.StateLogin, proto.Serverbound, &login.SetCompression{Threshold: 256}},
+			wantErr: true,
+		},
+		{
+			name:    "Wrong state",
+			args:    args{proto.StatePlay, proto.Clientbound, &login.SetCompression{Threshold: 256}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := proto.DefaultRegistry.Encode(769, tt.args.state, tt.args.direction, tt.args.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Registry.Encode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Registry.Encode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	newPing := func() proto.Message { return new(status.PingRequest) }
+	newPong := func() proto.Message { return new(status.PingResponse) }
+	tests := []struct {
+		name      string
+		register  func(r *proto.Registry)
+		wantPanic bool
+	}{
+		{
+			name: "Same ID in both directions",
+			register: func(r *proto.Registry) {
+				r.Register(768, proto.StateStatus, proto.Serverbound, 0x01, newPing)
+				r.Register(768, proto.StateStatus, proto.Clientbound, 0x01, newPong)
+			},
+		},
+		{
+			name: "Same packet in two versions",
+			register: func(r *proto.Registry) {
+				r.Register(768, proto.StateStatus, proto.Serverbound, 0x01, newPing)
+				r.Register(769, proto.StateStatus, proto.Serverbound, 0x01, newPing)
+			},
+		},
+		{
+			name: "Duplicate ID",
+			register: func(r *proto.Registry) {
+				r.Register(768, proto.StateStatus, proto.Serverbound, 0x01, newPing)
+				r.Register(768, proto.StateStatus, proto.Serverbound, 0x01, newPong)
+			},
+			wantPanic: true,
+		},
+		{
+			name: "Duplicate type",
+			register: func(r *proto.Registry) {
+				r.Register(768, proto.StateStatus, proto.Serverbound, 0x01, newPing)
+				r.Register(768, proto.StateStatus, proto.Serverbound, 0x02, newPing)
+			},
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("Registry.Register() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			tt.register(proto.NewRegistry())
+		})
+	}
+}
+
+func TestUnknownPacketError_Error(t *testing.T) {
+	err := &proto.UnknownPacketError{Version: 768, State: proto.StatePlay, Direction: proto.Serverbound, ID: 0x7F}
+	want := "unknown serverbound packet 0x7f in play state of protocol 768"
+	if got := err.Error(); got != want {
+		t.Errorf("UnknownPacketError.Error() = %v, want %v", got, want)
+	}
+}
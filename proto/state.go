@@ -0,0 +1,30 @@
+package proto
+
+import "strconv"
+
+type State int32
+
+const (
+	StateHandshaking State = iota
+	StateStatus
+	StateLogin
+	StateConfiguration
+	StatePlay
+)
+
+func (s State) String() string {
+	switch s {
+	case StateHandshaking:
+		return "handshaking"
+	case StateStatus:
+		return "status"
+	case StateLogin:
+		return "login"
+	case StateConfiguration:
+		return "configuration"
+	case StatePlay:
+		return "play"
+	default:
+		return "State(" + strconv.Itoa(int(s)) + ")"
+	}
+}
@@ -0,0 +1,28 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+)
+
+func TestState_String(t *testing.T) {
+	tests := []struct {
+		s    proto.State
+		want string
+	}{
+		{s: proto.StateHandshaking, want: "handshaking"},
+		{s: proto.StateStatus, want: "status"},
+		{s: proto.StateLogin, want: "login"},
+		{s: proto.StateConfiguration, want: "configuration"},
+		{s: proto.StatePlay, want: "play"},
+		{s: proto.State(9), want: "State(9)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.s.String(); got != tt.want {
+				t.Errorf("State.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package status
+
+import (
+	"encoding/json"
+
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+// Response is the JSON document carried by StatusResponse.
+type Response struct {
+	Version            Version        `json:"version"`
+	Players            *Players       `json:"players,omitempty"`
+	Description        text.Component `json:"description"`
+	Favicon            string         `json:"favicon,omitempty"`
+	EnforcesSecureChat bool           `json:"enforcesSecureChat"`
+}
+
+type Version struct {
+	Name     string `json:"name"`
+	Protocol int32  `json:"protocol"`
+}
+
+type Players struct {
+	Max    int            `json:"max"`
+	Online int            `json:"online"`
+	Sample []PlayerSample `json:"sample,omitempty"`
+}
+
+type PlayerSample struct {
+	Name string     `json:"name"`
+	ID   types.UUID `json:"id"`
+}
+
+func NewStatusResponse(r Response) (StatusResponse, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return StatusResponse{}, err
+	}
+	return StatusResponse{JSONResponse: string(data)}, nil
+}
+
+func (s *StatusResponse) Response() (Response, error) {
+	var r Response
+	err := json.Unmarshal([]byte(s.JSONResponse), &r)
+	return r, err
+}
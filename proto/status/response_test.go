@@ -0,0 +1,97 @@
+package status_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/status"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestNewStatusResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       status.Response
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Version only",
+			r:    status.Response{Version: status.Version{Name: "1.21.3", Protocol: 768}},
+			want: `{"version":{"name":"1.21.3","protocol":768},"description":{"text":""},"enforcesSecureChat":false}`,
+		},
+		{
+			name: "Full response",
+			r: status.Response{
+				Version: status.Version{Name: "1.21.3", Protocol: 768},
+				Players: &status.Players{
+					Max:    20,
+					Online: 1,
+					Sample: []status.PlayerSample{{Name: "Notch", ID: types.UUID{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5}}},
+				},
+				Description:        text.Plain("Hello"),
+				Favicon:            "data:image/png;base64,AA==",
+				EnforcesSecureChat: true,
+			},
+			want: `{"version":{"name":"1.21.3","protocol":768},"players":{"max":20,"online":1,"sample":[{"name":"Notch","id":"069a79f4-44e9-4726-a5be-fca90e38aaf5"}]},"description":{"text":"Hello"},"favicon":"data:image/png;base64,AA==","enforcesSecureChat":true}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := status.NewStatusResponse(tt.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewStatusResponse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.JSONResponse != tt.want {
+				t.Errorf("NewStatusResponse() = %v, want %v", got.JSONResponse, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusResponse_Response(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    status.Response
+		wantErr bool
+	}{
+		{
+			name: "Object description",
+			json: `{"version":{"name":"1.16.5","protocol":754},"players":{"max":100,"online":5},"description":{"text":"Welcome to the server!"}}`,
+			want: status.Response{
+				Version:     status.Version{Name: "1.16.5", Protocol: 754},
+				Players:     &status.Players{Max: 100, Online: 5},
+				Description: text.Plain("Welcome to the server!"),
+			},
+		},
+		{
+			name: "String description",
+			json: `{"version":{"name":"1.8.9","protocol":47},"description":"A Minecraft Server"}`,
+			want: status.Response{
+				Version:     status.Version{Name: "1.8.9", Protocol: 47},
+				Description: text.Plain("A Minecraft Server"),
+			},
+		},
+		{
+			name:    "Invalid JSON",
+			json:    `{`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &status.StatusResponse{JSONResponse: tt.json}
+			got, err := s.Response()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StatusResponse.Response() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StatusResponse.Response() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
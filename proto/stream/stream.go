@@ -1,6 +1,9 @@
 package stream
 
-import "io"
+import (
+	"io"
+	"slices"
+)
 
 func ReadAll(r io.Reader, readers ...io.ReaderFrom) (int64, error) {
 	var totalRead int64
@@ -25,3 +28,24 @@ func WriteAll(w io.Writer, writers ...io.WriterTo) (int64, error) {
 	}
 	return totalWritten, nil
 }
+
+// ReadBytes reads n bytes from r in chunks, so that a forged length costs
+// no more memory than the data actually sent. On error it returns the
+// bytes read so far; io.EOF is only returned if none were.
+func ReadBytes(r io.Reader, n int) ([]byte, error) {
+	const chunkSize = 1 << 16
+	b := make([]byte, 0, min(n, chunkSize))
+	for len(b) < n {
+		chunk := min(n-len(b), chunkSize)
+		b = slices.Grow(b, chunk)
+		m, err := io.ReadFull(r, b[len(b):len(b)+chunk])
+		b = b[:len(b)+m]
+		if err != nil {
+			if err == io.EOF && len(b) > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return b, err
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,38 @@
+package stream_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/stream"
+)
+
+func TestReadBytes(t *testing.T) {
+	long := bytes.Repeat([]byte{0xAB}, 1<<17+3)
+	tests := []struct {
+		name    string
+		data    []byte
+		n       int
+		want    []byte
+		wantErr error
+	}{
+		{name: "Empty", data: nil, n: 0, want: []byte{}},
+		{name: "Exact", data: []byte{1, 2, 3}, n: 3, want: []byte{1, 2, 3}},
+		{name: "Several chunks", data: long, n: len(long), want: long},
+		{name: "No data", data: nil, n: 3, want: []byte{}, wantErr: io.EOF},
+		{name: "Forged length", data: []byte{1, 2}, n: 1<<31 - 1, want: []byte{1, 2}, wantErr: io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stream.ReadBytes(bytes.NewReader(tt.data), tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReadBytes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("ReadBytes() = %d bytes, want %d", len(got), len(tt.want))
+			}
+		})
+	}
+}
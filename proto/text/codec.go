@@ -0,0 +1,426 @@
+package text
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func (c Component) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.value(false))
+}
+
+// UnmarshalJSON accepts the object form as well as the plain string and
+// array shorthands.
+func (c *Component) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	component, err := fromValue(v)
+	if err != nil {
+		return err
+	}
+	*c = component
+	return nil
+}
+
+func (c Component) MarshalNBT() (any, error) {
+	return c.value(true), nil
+}
+
+func (c *Component) UnmarshalNBT(v any) error {
+	component, err := fromValue(v)
+	if err != nil {
+		return err
+	}
+	*c = component
+	return nil
+}
+
+// ReadFrom reads the network NBT encoding used by packets since 1.20.3.
+func (c *Component) ReadFrom(r io.Reader) (int64, error) {
+	d := nbt.NewDecoder(r)
+	err := d.Decode(c)
+	return d.InputOffset(), err
+}
+
+// WriteTo writes the network NBT encoding used by packets since 1.20.3.
+func (c *Component) WriteTo(w io.Writer) (int64, error) {
+	data, err := nbt.Marshal(c)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// MaxJSONLength is the longest JSON string accepted for a component sent
+// as JSON, such as the login disconnect reason.
+const MaxJSONLength = 262144
+
+// value returns the generic form of c. The NBT form stores entity UUIDs
+// as int arrays instead of strings.
+func (c Component) value(nbtForm bool) map[string]any {
+	m := map[string]any{}
+	switch {
+	case c.Translate != "":
+		m["translate"] = c.Translate
+		if c.Fallback != "" {
+			m["fallback"] = c.Fallback
+		}
+		if len(c.With) > 0 {
+			m["with"] = values(c.With, nbtForm)
+		}
+	case c.Score != nil:
+		m["score"] = map[string]any{"name": c.Score.Name, "objective": c.Score.Objective}
+	case c.Selector != "":
+		m["selector"] = c.Selector
+		if c.Separator != nil {
+			m["separator"] = c.Separator.value(nbtForm)
+		}
+	case c.Keybind != "":
+		m["keybind"] = c.Keybind
+	case c.NBT != "":
+		m["nbt"] = c.NBT
+		if c.Interpret {
+			m["interpret"] = true
+		}
+		if c.Separator != nil {
+			m["separator"] = c.Separator.value(nbtForm)
+		}
+		switch {
+		case c.Block != "":
+			m["block"] = c.Block
+		case c.Entity != "":
+			m["entity"] = c.Entity
+		case c.Storage != "":
+			m["storage"] = c.Storage
+		}
+	default:
+		m["text"] = c.Text
+	}
+
+	s := c.Style
+	if s.Color != "" {
+		m["color"] = string(s.Color)
+	}
+	for key, flag := range map[string]*bool{
+		"bold":          s.Bold,
+		"italic":        s.Italic,
+		"underlined":    s.Underlined,
+		"strikethrough": s.Strikethrough,
+		"obfuscated":    s.Obfuscated,
+	} {
+		if flag != nil {
+			m[key] = *flag
+		}
+	}
+	if s.Font != "" {
+		m["font"] = s.Font
+	}
+	if s.Insertion != "" {
+		m["insertion"] = s.Insertion
+	}
+	if s.ClickEvent != nil {
+		m["clickEvent"] = map[string]any{"action": string(s.ClickEvent.Action), "value": s.ClickEvent.Value}
+	}
+	if s.HoverEvent != nil {
+		m["hoverEvent"] = s.HoverEvent.value(nbtForm)
+	}
+
+	if len(c.Extra) > 0 {
+		m["extra"] = values(c.Extra, nbtForm)
+	}
+	return m
+}
+
+func values(components []Component, nbtForm bool) []any {
+	v := make([]any, len(components))
+	for i, c := range components {
+		v[i] = c.value(nbtForm)
+	}
+	return v
+}
+
+func (h HoverEvent) value(nbtForm bool) map[string]any {
+	m := map[string]any{"action": string(h.Action)}
+	switch {
+	case h.Text != nil:
+		m["contents"] = h.Text.value(nbtForm)
+	case h.Item != nil:
+		item := map[string]any{"id": h.Item.ID}
+		if h.Item.Count != 0 {
+			item["count"] = h.Item.Count
+		}
+		if len(h.Item.Components) > 0 {
+			item["components"] = h.Item.Components
+		}
+		m["contents"] = item
+	case h.Entity != nil:
+		entity := map[string]any{"type": h.Entity.Type}
+		if nbtForm {
+			entity["id"] = uuidInts(h.Entity.ID)
+		} else {
+			entity["id"] = h.Entity.ID.String()
+		}
+		if h.Entity.Name != nil {
+			entity["name"] = h.Entity.Name.value(nbtForm)
+		}
+		m["contents"] = entity
+	}
+	return m
+}
+
+// fromValue decodes the generic form produced by encoding/json or nbt.
+func fromValue(v any) (Component, error) {
+	switch v := v.(type) {
+	case string:
+		return Component{Text: v}, nil
+	case []any:
+		if len(v) == 0 {
+			return Component{}, ErrInvalidComponent
+		}
+		components, err := fromValues(v)
+		if err != nil {
+			return Component{}, err
+		}
+		c := components[0]
+		c.Extra = append(c.Extra, components[1:]...)
+		return c, nil
+	case map[string]any:
+		return fromMap(v)
+	case bool, float64, int8, int16, int32, int64, float32:
+		return Component{Text: fmt.Sprint(v)}, nil
+	}
+	return Component{}, ErrInvalidComponent
+}
+
+func fromValues(v any) ([]Component, error) {
+	list, ok := v.([]any)
+	if !ok {
+		return nil, ErrInvalidComponent
+	}
+	components := make([]Component, len(list))
+	for i, item := range list {
+		c, err := fromValue(item)
+		if err != nil {
+			return nil, err
+		}
+		components[i] = c
+	}
+	return components, nil
+}
+
+func fromMap(m map[string]any) (Component, error) {
+	// Vanilla wraps the elements of NBT lists that mix strings and
+	// compounds in a compound with an empty key.
+	if inner, ok := m[""]; ok && len(m) == 1 {
+		return fromValue(inner)
+	}
+
+	var c Component
+	var err error
+	kind, _ := m["type"].(string)
+	switch {
+	case kind == "translatable" || kind == "" && m["translate"] != nil:
+		c.Translate, _ = m["translate"].(string)
+		c.Fallback, _ = m["fallback"].(string)
+		if with, ok := m["with"]; ok {
+			if c.With, err = fromValues(with); err != nil {
+				return Component{}, err
+			}
+		}
+	case kind == "score" || kind == "" && m["score"] != nil:
+		score, ok := m["score"].(map[string]any)
+		if !ok {
+			return Component{}, ErrInvalidComponent
+		}
+		c.Score = &Score{}
+		c.Score.Name, _ = score["name"].(string)
+		c.Score.Objective, _ = score["objective"].(string)
+	case kind == "selector" || kind == "" && m["selector"] != nil:
+		c.Selector, _ = m["selector"].(string)
+	case kind == "keybind" || kind == "" && m["keybind"] != nil:
+		c.Keybind, _ = m["keybind"].(string)
+	case kind == "nbt" || kind == "" && m["nbt"] != nil:
+		c.NBT, _ = m["nbt"].(string)
+		c.Interpret = boolValue(m["interpret"])
+		c.Block, _ = m["block"].(string)
+		c.Entity, _ = m["entity"].(string)
+		c.Storage, _ = m["storage"].(string)
+	default:
+		text, ok := m["text"]
+		if !ok {
+			return Component{}, ErrInvalidComponent
+		}
+		if c.Text, ok = text.(string); !ok {
+			c.Text = fmt.Sprint(text)
+		}
+	}
+	if separator, ok := m["separator"]; ok {
+		sep, err := fromValue(separator)
+		if err != nil {
+			return Component{}, err
+		}
+		c.Separator = &sep
+	}
+
+	if c.Style, err = styleFromMap(m); err != nil {
+		return Component{}, err
+	}
+	if extra, ok := m["extra"]; ok {
+		if c.Extra, err = fromValues(extra); err != nil {
+			return Component{}, err
+		}
+	}
+	return c, nil
+}
+
+func styleFromMap(m map[string]any) (Style, error) {
+	var s Style
+	if color, ok := m["color"].(string); ok {
+		s.Color = Color(color)
+	}
+	for key, flag := range map[string]**bool{
+		"bold":          &s.Bold,
+		"italic":        &s.Italic,
+		"underlined":    &s.Underlined,
+		"strikethrough": &s.Strikethrough,
+		"obfuscated":    &s.Obfuscated,
+	} {
+		if v, ok := m[key]; ok {
+			b := boolValue(v)
+			*flag = &b
+		}
+	}
+	s.Font, _ = m["font"].(string)
+	s.Insertion, _ = m["insertion"].(string)
+
+	if click, ok := m["clickEvent"].(map[string]any); ok {
+		action, _ := click["action"].(string)
+		s.ClickEvent = &ClickEvent{Action: ClickAction(action)}
+		if s.ClickEvent.Value, ok = click["value"].(string); !ok && click["value"] != nil {
+			s.ClickEvent.Value = fmt.Sprint(click["value"])
+		}
+	}
+	if hover, ok := m["hoverEvent"].(map[string]any); ok {
+		h, err := hoverFromMap(hover)
+		if err != nil {
+			return Style{}, err
+		}
+		s.HoverEvent = &h
+	}
+	return s, nil
+}
+
+func hoverFromMap(m map[string]any) (HoverEvent, error) {
+	action, _ := m["action"].(string)
+	h := HoverEvent{Action: HoverAction(action)}
+	contents, ok := m["contents"]
+	if !ok {
+		return h, nil
+	}
+	switch h.Action {
+	case ShowText:
+		c, err := fromValue(contents)
+		if err != nil {
+			return HoverEvent{}, err
+		}
+		h.Text = &c
+	case ShowItem:
+		h.Item = &HoverItem{}
+		switch contents := contents.(type) {
+		case string:
+			h.Item.ID = contents
+		case map[string]any:
+			h.Item.ID, _ = contents["id"].(string)
+			h.Item.Count = int32(number(contents["count"]))
+			h.Item.Components, _ = contents["components"].(map[string]any)
+		}
+	case ShowEntity:
+		entity, ok := contents.(map[string]any)
+		if !ok {
+			return HoverEvent{}, ErrInvalidComponent
+		}
+		h.Entity = &HoverEntity{}
+		h.Entity.Type, _ = entity["type"].(string)
+		id, err := uuidValue(entity["id"])
+		if err != nil {
+			return HoverEvent{}, err
+		}
+		h.Entity.ID = id
+		if name, ok := entity["name"]; ok {
+			c, err := fromValue(name)
+			if err != nil {
+				return HoverEvent{}, err
+			}
+			h.Entity.Name = &c
+		}
+	}
+	return h, nil
+}
+
+func boolValue(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return number(v) != 0
+}
+
+func number(v any) int64 {
+	switch v := v.(type) {
+	case float64:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	}
+	return 0
+}
+
+func uuidInts(id types.UUID) []int32 {
+	ints := make([]int32, 4)
+	for i := range ints {
+		ints[i] = int32(binary.BigEndian.Uint32(id[i*4:]))
+	}
+	return ints
+}
+
+// uuidValue accepts a UUID string or the four-int array form.
+func uuidValue(v any) (types.UUID, error) {
+	var id types.UUID
+	var ints []int64
+	switch v := v.(type) {
+	case string:
+		err := id.UnmarshalText([]byte(v))
+		return id, err
+	case []int32:
+		for _, i := range v {
+			ints = append(ints, int64(i))
+		}
+	case []any:
+		for _, i := range v {
+			ints = append(ints, number(i))
+		}
+	}
+	if len(ints) != 4 {
+		return id, ErrInvalidComponent
+	}
+	for i, n := range ints {
+		binary.BigEndian.PutUint32(id[i*4:], uint32(n))
+	}
+	return id, nil
+}
@@ -0,0 +1,251 @@
+package text_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/nbt"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+var testUUID = types.UUID{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestComponent_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		c    text.Component
+		want string
+	}{
+		{
+			name: "Plain text",
+			c:    text.Plain("Hi"),
+			want: `{"text":"Hi"}`,
+		},
+		{
+			name: "Styled text with children",
+			c: text.Component{
+				Text:  "Hello ",
+				Style: text.Style{Color: text.Red, Bold: boolPtr(true)},
+				Extra: []text.Component{{Text: "world", Style: text.Style{Italic: boolPtr(false)}}},
+			},
+			want: `{"bold":true,"color":"red","extra":[{"italic":false,"text":"world"}],"text":"Hello "}`,
+		},
+		{
+			name: "Translatable",
+			c:    text.Translatable("chat.type.text", text.Plain("Notch"), text.Plain("hi")),
+			want: `{"translate":"chat.type.text","with":[{"text":"Notch"},{"text":"hi"}]}`,
+		},
+		{
+			name: "Score",
+			c:    text.Component{Score: &text.Score{Name: "@p", Objective: "kills"}},
+			want: `{"score":{"name":"@p","objective":"kills"}}`,
+		},
+		{
+			name: "Selector",
+			c:    text.Component{Selector: "@a", Separator: &text.Component{Text: ", "}},
+			want: `{"selector":"@a","separator":{"text":", "}}`,
+		},
+		{
+			name: "Keybind",
+			c:    text.Component{Keybind: "key.jump"},
+			want: `{"keybind":"key.jump"}`,
+		},
+		{
+			name: "NBT",
+			c:    text.Component{NBT: "Inventory[0]", Interpret: true, Entity: "@s"},
+			want: `{"entity":"@s","interpret":true,"nbt":"Inventory[0]"}`,
+		},
+		{
+			name: "Click and hover events",
+			c: text.Component{Text: "x", Style: text.Style{
+				Color:      text.HexColor(0x12AB34),
+				ClickEvent: &text.ClickEvent{Action: text.OpenURL, Value: "https://example.com"},
+				HoverEvent: &text.HoverEvent{Action: text.ShowEntity, Entity: &text.HoverEntity{Type: "minecraft:pig", ID: testUUID}},
+			}},
+			want: `{"clickEvent":{"action":"open_url","value":"https://example.com"},"color":"#12AB34",` +
+				`"hoverEvent":{"action":"show_entity","contents":{"id":"069a79f4-44e9-4726-a5be-fca90e38aaf5","type":"minecraft:pig"}},"text":"x"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.c)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", got, tt.want)
+			}
+
+			var decoded text.Component
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.c) {
+				t.Errorf("json.Unmarshal() = %+v, want %+v", decoded, tt.c)
+			}
+		})
+	}
+}
+
+func TestComponent_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    text.Component
+		wantErr bool
+	}{
+		{
+			name: "String shorthand",
+			data: `"Hi"`,
+			want: text.Plain("Hi"),
+		},
+		{
+			name: "Array shorthand",
+			data: `["a", {"text": "b", "color": "red"}]`,
+			want: text.Component{Text: "a", Extra: []text.Component{{Text: "b", Style: text.Style{Color: text.Red}}}},
+		},
+		{
+			name: "Explicit type",
+			data: `{"type": "keybind", "keybind": "key.jump"}`,
+			want: text.Component{Keybind: "key.jump"},
+		},
+		{
+			name: "Primitive translation arguments",
+			data: `{"translate": "%s", "with": [3, true]}`,
+			want: text.Translatable("%s", text.Plain("3"), text.Plain("true")),
+		},
+		{
+			name: "Hover text",
+			data: `{"text": "", "hoverEvent": {"action": "show_text", "contents": "tip"}}`,
+			want: text.Component{Style: text.Style{HoverEvent: &text.HoverEvent{Action: text.ShowText, Text: &text.Component{Text: "tip"}}}},
+		},
+		{
+			name: "Hover item",
+			data: `{"text": "", "hoverEvent": {"action": "show_item", "contents": {"id": "minecraft:stone", "count": 2}}}`,
+			want: text.Component{Style: text.Style{HoverEvent: &text.HoverEvent{Action: text.ShowItem, Item: &text.HoverItem{ID: "minecraft:stone", Count: 2}}}},
+		},
+		{
+			name:    "Empty array",
+			data:    `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "No content",
+			data:    `{"color": "red"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Null",
+			data:    `null`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got text.Component
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("json.Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("json.Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponent_NBT(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        text.Component
+		wantSNBT string
+	}{
+		{
+			name:     "Plain text",
+			c:        text.Plain("Hi"),
+			wantSNBT: `{text:"Hi"}`,
+		},
+		{
+			name: "Styled text with children",
+			c: text.Component{
+				Text:  "Hello ",
+				Style: text.Style{Color: text.Red, Bold: boolPtr(true)},
+				Extra: []text.Component{{Text: "world"}},
+			},
+			wantSNBT: `{bold:1b,color:"red",extra:[{text:"world"}],text:"Hello "}`,
+		},
+		{
+			name: "Hover entity",
+			c: text.Component{Style: text.Style{
+				HoverEvent: &text.HoverEvent{Action: text.ShowEntity, Entity: &text.HoverEntity{Type: "minecraft:pig", ID: testUUID}},
+			}},
+			wantSNBT: `{hoverEvent:{action:"show_entity",contents:{id:[I;110787060,1156138790,-1514210135,238594805],type:"minecraft:pig"}},text:""}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nbt.MarshalSNBT(tt.c)
+			if err != nil {
+				t.Fatalf("nbt.MarshalSNBT() error = %v", err)
+			}
+			if got != tt.wantSNBT {
+				t.Errorf("nbt.MarshalSNBT() = %v, want %v", got, tt.wantSNBT)
+			}
+
+			var buf bytes.Buffer
+			n, err := tt.c.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("Component.WriteTo() error = %v", err)
+			}
+			var decoded text.Component
+			m, err := decoded.ReadFrom(&buf)
+			if err != nil {
+				t.Fatalf("Component.ReadFrom() error = %v", err)
+			}
+			if m != n {
+				t.Errorf("Component.ReadFrom() = %v, want %v", m, n)
+			}
+			if !reflect.DeepEqual(decoded, tt.c) {
+				t.Errorf("Component.ReadFrom() = %+v, want %+v", decoded, tt.c)
+			}
+		})
+	}
+}
+
+func TestComponent_UnmarshalNBT(t *testing.T) {
+	tests := []struct {
+		name string
+		snbt string
+		want text.Component
+	}{
+		{
+			name: "String tag",
+			snbt: `"Hi"`,
+			want: text.Plain("Hi"),
+		},
+		{
+			name: "Wrapped list elements",
+			snbt: `{text:"a",extra:[{"":"b"},{text:"c",italic:1b}]}`,
+			want: text.Component{Text: "a", Extra: []text.Component{{Text: "b"}, {Text: "c", Style: text.Style{Italic: boolPtr(true)}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got text.Component
+			if err := nbt.UnmarshalSNBT(tt.snbt, &got); err != nil {
+				t.Fatalf("nbt.UnmarshalSNBT() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nbt.UnmarshalSNBT() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package text implements Minecraft text components, the rich text used by
+// the server list, chat, titles and disconnect screens.
+package text
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidComponent = errors.New("text: invalid component")
+)
+
+// Component is a text component. Its content is the first of Translate,
+// Score, Selector, Keybind and NBT that is set, or Text otherwise. Extra
+// children inherit the component's style.
+type Component struct {
+	Text string
+
+	Translate string
+	Fallback  string
+	With      []Component
+
+	Score *Score
+
+	Selector string
+	// Separator joins multiple matches of a selector or NBT path.
+	Separator *Component
+
+	Keybind string
+
+	NBT       string
+	Interpret bool
+	Block     string
+	Entity    string
+	Storage   string
+
+	Style
+	Extra []Component
+}
+
+type Score struct {
+	Name      string
+	Objective string
+}
+
+func Plain(s string) Component {
+	return Component{Text: s}
+}
+
+func Translatable(key string, with ...Component) Component {
+	return Component{Translate: key, With: with}
+}
+
+// PlainText returns the component's text without styling. Translatable
+// components use their fallback, or their key when there is none.
+func (c Component) PlainText() string {
+	var sb strings.Builder
+	c.walk(Style{}, func(s string, _ Style) {
+		sb.WriteString(s)
+	})
+	return sb.String()
+}
+
+// walk calls fn with the text content and effective style of c and each
+// of its children in order.
+func (c Component) walk(parent Style, fn func(s string, style Style)) {
+	style := c.Style.inherit(parent)
+	fn(c.content(), style)
+	for _, child := range c.Extra {
+		child.walk(style, fn)
+	}
+}
+
+func (c Component) content() string {
+	switch {
+	case c.Translate != "":
+		if c.Fallback != "" {
+			return c.Fallback
+		}
+		return c.Translate
+	case c.Score != nil:
+		return ""
+	case c.Selector != "":
+		return c.Selector
+	case c.Keybind != "":
+		return c.Keybind
+	case c.NBT != "":
+		return ""
+	default:
+		return c.Text
+	}
+}
@@ -0,0 +1,38 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func TestComponent_PlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		c    text.Component
+		want string
+	}{
+		{
+			name: "Children",
+			c:    text.ParseLegacy("§6Gold §lbold"),
+			want: "Gold bold",
+		},
+		{
+			name: "Translatable fallback",
+			c:    text.Component{Translate: "custom.key", Fallback: "Fallback"},
+			want: "Fallback",
+		},
+		{
+			name: "Translatable key",
+			c:    text.Translatable("custom.key"),
+			want: "custom.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.PlainText(); got != tt.want {
+				t.Errorf("Component.PlainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LegacyCode is the section sign that starts a legacy formatting code.
+const LegacyCode = '§'
+
+var legacyColors = map[rune]Color{
+	'0': Black,
+	'1': DarkBlue,
+	'2': DarkGreen,
+	'3': DarkAqua,
+	'4': DarkRed,
+	'5': DarkPurple,
+	'6': Gold,
+	'7': Gray,
+	'8': DarkGray,
+	'9': Blue,
+	'a': Green,
+	'b': Aqua,
+	'c': Red,
+	'd': LightPurple,
+	'e': Yellow,
+	'f': White,
+}
+
+// ParseLegacy converts a string with legacy codes such as "§cRed §lbold"
+// into a component. As in the old format, a color code clears any
+// formatting before it and §r resets everything. Unknown codes are kept
+// as text.
+func ParseLegacy(s string) Component {
+	var parts []Component
+	var style Style
+	var sb strings.Builder
+	flush := func() {
+		if sb.Len() > 0 {
+			parts = append(parts, Component{Text: sb.String(), Style: style})
+			sb.Reset()
+		}
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != LegacyCode || i+1 == len(runes) {
+			sb.WriteRune(runes[i])
+			continue
+		}
+		code := unicode.ToLower(runes[i+1])
+		if color, ok := legacyColors[code]; ok {
+			flush()
+			style = Style{Color: color}
+			i++
+			continue
+		}
+		t := true
+		switch code {
+		case 'k':
+			flush()
+			style.Obfuscated = &t
+		case 'l':
+			flush()
+			style.Bold = &t
+		case 'm':
+			flush()
+			style.Strikethrough = &t
+		case 'n':
+			flush()
+			style.Underlined = &t
+		case 'o':
+			flush()
+			style.Italic = &t
+		case 'r':
+			flush()
+			style = Style{}
+		default:
+			sb.WriteRune(runes[i])
+			continue
+		}
+		i++
+	}
+	flush()
+
+	switch len(parts) {
+	case 0:
+		return Component{}
+	case 1:
+		return parts[0]
+	default:
+		return Component{Extra: parts}
+	}
+}
+
+// Legacy renders c with legacy codes, for clients that predate
+// components such as the legacy server list ping. Hex colors and
+// non-text content are dropped.
+func (c Component) Legacy() string {
+	var sb strings.Builder
+	var last string
+	c.walk(Style{}, func(s string, style Style) {
+		if s == "" {
+			return
+		}
+		if codes := style.legacyCodes(); codes != last {
+			if last != "" && style.legacyColor() == 0 {
+				sb.WriteString(string(LegacyCode) + "r")
+			}
+			sb.WriteString(codes)
+			last = codes
+		}
+		sb.WriteString(s)
+	})
+	return sb.String()
+}
+
+func (s Style) legacyColor() rune {
+	for code, color := range legacyColors {
+		if color == s.Color {
+			return code
+		}
+	}
+	return 0
+}
+
+func (s Style) legacyCodes() string {
+	var sb strings.Builder
+	if code := s.legacyColor(); code != 0 {
+		sb.WriteString(string([]rune{LegacyCode, code}))
+	}
+	for _, format := range []struct {
+		flag *bool
+		code rune
+	}{
+		{s.Obfuscated, 'k'},
+		{s.Bold, 'l'},
+		{s.Strikethrough, 'm'},
+		{s.Underlined, 'n'},
+		{s.Italic, 'o'},
+	} {
+		if format.flag != nil && *format.flag {
+			sb.WriteString(string([]rune{LegacyCode, format.code}))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+package text_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func TestParseLegacy(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want text.Component
+	}{
+		{
+			name: "Plain",
+			s:    "A Minecraft Server",
+			want: text.Plain("A Minecraft Server"),
+		},
+		{
+			name: "Empty",
+			s:    "",
+			want: text.Component{},
+		},
+		{
+			name: "Single color",
+			s:    "§cRed",
+			want: text.Component{Text: "Red", Style: text.Style{Color: text.Red}},
+		},
+		{
+			name: "Color clears formatting",
+			s:    "§l§6Gold §lbold§aGreen",
+			want: text.Component{Extra: []text.Component{
+				{Text: "Gold ", Style: text.Style{Color: text.Gold}},
+				{Text: "bold", Style: text.Style{Color: text.Gold, Bold: boolPtr(true)}},
+				{Text: "Green", Style: text.Style{Color: text.Green}},
+			}},
+		},
+		{
+			name: "Reset and uppercase codes",
+			s:    "§ODim§r plain",
+			want: text.Component{Extra: []text.Component{
+				{Text: "Dim", Style: text.Style{Italic: boolPtr(true)}},
+				{Text: " plain"},
+			}},
+		},
+		{
+			name: "Unknown and trailing codes",
+			s:    "§zab§",
+			want: text.Plain("§zab§"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := text.ParseLegacy(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLegacy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponent_Legacy(t *testing.T) {
+	tests := []struct {
+		name string
+		c    text.Component
+		want string
+	}{
+		{
+			name: "Plain",
+			c:    text.Plain("Hi"),
+			want: "Hi",
+		},
+		{
+			name: "Inherited style",
+			c: text.Component{
+				Text:  "Red ",
+				Style: text.Style{Color: text.Red},
+				Extra: []text.Component{{Text: "bold", Style: text.Style{Bold: boolPtr(true)}}},
+			},
+			want: "§cRed §c§lbold",
+		},
+		{
+			name: "Reset after formatting",
+			c: text.Component{Extra: []text.Component{
+				{Text: "bold", Style: text.Style{Bold: boolPtr(true)}},
+				{Text: " plain"},
+			}},
+			want: "§lbold§r plain",
+		},
+		{
+			name: "Round trip",
+			c:    text.ParseLegacy("§6Gold §lbold§aGreen"),
+			want: "§6Gold §6§lbold§aGreen",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Legacy(); got != tt.want {
+				t.Errorf("Component.Legacy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package text
+
+import (
+	"fmt"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+// Style holds the formatting of a component. Nil flags and empty fields
+// are inherited from the parent component.
+type Style struct {
+	Color         Color
+	Bold          *bool
+	Italic        *bool
+	Underlined    *bool
+	Strikethrough *bool
+	Obfuscated    *bool
+	Font          string
+	Insertion     string
+	ClickEvent    *ClickEvent
+	HoverEvent    *HoverEvent
+}
+
+// Color is one of the named colors below or a "#RRGGBB" hex color.
+type Color string
+
+const (
+	Black       Color = "black"
+	DarkBlue    Color = "dark_blue"
+	DarkGreen   Color = "dark_green"
+	DarkAqua    Color = "dark_aqua"
+	DarkRed     Color = "dark_red"
+	DarkPurple  Color = "dark_purple"
+	Gold        Color = "gold"
+	Gray        Color = "gray"
+	DarkGray    Color = "dark_gray"
+	Blue        Color = "blue"
+	Green       Color = "green"
+	Aqua        Color = "aqua"
+	Red         Color = "red"
+	LightPurple Color = "light_purple"
+	Yellow      Color = "yellow"
+	White       Color = "white"
+)
+
+func HexColor(rgb uint32) Color {
+	return Color(fmt.Sprintf("#%06X", rgb&0xFFFFFF))
+}
+
+type ClickAction string
+
+const (
+	OpenURL         ClickAction = "open_url"
+	RunCommand      ClickAction = "run_command"
+	SuggestCommand  ClickAction = "suggest_command"
+	ChangePage      ClickAction = "change_page"
+	CopyToClipboard ClickAction = "copy_to_clipboard"
+)
+
+type ClickEvent struct {
+	Action ClickAction
+	Value  string
+}
+
+type HoverAction string
+
+const (
+	ShowText   HoverAction = "show_text"
+	ShowItem   HoverAction = "show_item"
+	ShowEntity HoverAction = "show_entity"
+)
+
+// HoverEvent shows Text, Item or Entity depending on Action.
+type HoverEvent struct {
+	Action HoverAction
+	Text   *Component
+	Item   *HoverItem
+	Entity *HoverEntity
+}
+
+type HoverItem struct {
+	ID         string
+	Count      int32
+	Components map[string]any
+}
+
+type HoverEntity struct {
+	Type string
+	ID   types.UUID
+	Name *Component
+}
+
+func (s Style) inherit(parent Style) Style {
+	if s.Color == "" {
+		s.Color = parent.Color
+	}
+	if s.Bold == nil {
+		s.Bold = parent.Bold
+	}
+	if s.Italic == nil {
+		s.Italic = parent.Italic
+	}
+	if s.Underlined == nil {
+		s.Underlined = parent.Underlined
+	}
+	if s.Strikethrough == nil {
+		s.Strikethrough = parent.Strikethrough
+	}
+	if s.Obfuscated == nil {
+		s.Obfuscated = parent.Obfuscated
+	}
+	if s.Font == "" {
+		s.Font = parent.Font
+	}
+	if s.Insertion == "" {
+		s.Insertion = parent.Insertion
+	}
+	if s.ClickEvent == nil {
+		s.ClickEvent = parent.ClickEvent
+	}
+	if s.HoverEvent == nil {
+		s.HoverEvent = parent.HoverEvent
+	}
+	return s
+}
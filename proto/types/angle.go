@@ -0,0 +1,29 @@
+package types
+
+import "io"
+
+// Angle is a rotation in steps of 1/256 of a full turn.
+type Angle uint8
+
+func AngleFromDegrees(degrees float32) Angle {
+	return Angle(int(degrees * 256 / 360))
+}
+
+func (a Angle) Degrees() float32 {
+	return float32(a) * 360 / 256
+}
+
+func (a *Angle) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 1)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*a = Angle(buffer[0])
+	return int64(n), nil
+}
+
+func (a *Angle) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write([]byte{byte(*a)})
+	return int64(n), err
+}
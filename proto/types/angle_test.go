@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestAngle_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		a            *types.Angle
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified uint8
+	}{
+		{
+			name:         "Zero",
+			a:            new(types.Angle),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "Half turn",
+			a:            new(types.Angle),
+			args:         args{bytes.NewReader([]byte{0x80})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 128,
+		},
+		{
+			name:         "Empty reader",
+			a:            new(types.Angle),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Angle.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Angle.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if uint8(*tt.a) != tt.wantModified {
+				t.Errorf("Angle.ReadFrom() modified a = %v, want %v", *tt.a, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestAngle_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       types.Angle
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{name: "Zero", a: 0, want: 1, wantW: []byte{0x00}},
+		{name: "Quarter turn", a: 64, want: 1, wantW: []byte{0x40}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.a.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Angle.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Angle.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Angle.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestAngle_Degrees(t *testing.T) {
+	tests := []struct {
+		degrees float32
+		want    types.Angle
+	}{
+		{degrees: 0, want: 0},
+		{degrees: 90, want: 64},
+		{degrees: 180, want: 128},
+		{degrees: -90, want: 192},
+		{degrees: 450, want: 64},
+	}
+	for _, tt := range tests {
+		if got := types.AngleFromDegrees(tt.degrees); got != tt.want {
+			t.Errorf("AngleFromDegrees(%v) = %v, want %v", tt.degrees, got, tt.want)
+		}
+	}
+	if got := types.Angle(64).Degrees(); got != 90 {
+		t.Errorf("Angle.Degrees() = %v, want 90", got)
+	}
+}
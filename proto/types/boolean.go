@@ -0,0 +1,24 @@
+package types
+
+import "io"
+
+type Boolean bool
+
+func (b *Boolean) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 1)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*b = buffer[0] != 0x00
+	return int64(n), nil
+}
+
+func (b *Boolean) WriteTo(w io.Writer) (int64, error) {
+	buffer := []byte{0x00}
+	if *b {
+		buffer[0] = 0x01
+	}
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
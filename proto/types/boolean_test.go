@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestBoolean_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		b            *types.Boolean
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified bool
+	}{
+		{
+			name:         "False",
+			b:            new(types.Boolean),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      false,
+			wantModified: false,
+		},
+		{
+			name:         "True",
+			b:            new(types.Boolean),
+			args:         args{bytes.NewReader([]byte{0x01})},
+			want:         1,
+			wantErr:      false,
+			wantModified: true,
+		},
+		{
+			name:         "Empty reader",
+			b:            new(types.Boolean),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Boolean.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Boolean.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if bool(*tt.b) != tt.wantModified {
+				t.Errorf("Boolean.ReadFrom() modified b = %v, want %v", *tt.b, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestBoolean_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       *types.Boolean
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "False",
+			b:     newBoolean(false),
+			want:  1,
+			wantW: []byte{0x00},
+		},
+		{
+			name:  "True",
+			b:     newBoolean(true),
+			want:  1,
+			wantW: []byte{0x01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.b.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Boolean.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Boolean.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Boolean.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newBoolean(b bool) *types.Boolean {
+	tb := types.Boolean(b)
+	return &tb
+}
@@ -0,0 +1,22 @@
+package types
+
+import "io"
+
+type Byte int8
+
+func (b *Byte) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 1)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*b = Byte(int8(buffer[0]))
+	return int64(n), nil
+}
+
+func (b *Byte) WriteTo(w io.Writer) (int64, error) {
+	buffer := make([]byte, 1)
+	buffer[0] = byte(*b)
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
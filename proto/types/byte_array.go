@@ -0,0 +1,51 @@
+package types
+
+import (
+	"errors"
+	"io"
+
+	"github.com/nonya123456/cobble/proto/stream"
+)
+
+var (
+	ErrInvalidByteArrayLength = errors.New("invalid byte array length")
+)
+
+type ByteArray []byte
+
+func (b *ByteArray) ReadFrom(r io.Reader) (int64, error) {
+	var totalRead int64
+	var length VarInt
+	n1, err := length.ReadFrom(r)
+	totalRead += n1
+	if err != nil {
+		return totalRead, err
+	}
+	if length < 0 {
+		return totalRead, ErrInvalidByteArrayLength
+	}
+
+	data, err := stream.ReadBytes(r, int(length))
+	totalRead += int64(len(data))
+	if err != nil {
+		return totalRead, err
+	}
+	*b = data
+	return totalRead, nil
+}
+
+func (b *ByteArray) WriteTo(w io.Writer) (int64, error) {
+	var totalWrite int64
+	length := VarInt(len(*b))
+	n1, err := length.WriteTo(w)
+	totalWrite += n1
+	if err != nil {
+		return totalWrite, err
+	}
+	n2, err := w.Write(*b)
+	totalWrite += int64(n2)
+	if err != nil {
+		return totalWrite, err
+	}
+	return totalWrite, nil
+}
@@ -0,0 +1,119 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestByteArray_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		b            *types.ByteArray
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified []byte
+	}{
+		{
+			name:         "Empty array",
+			b:            new(types.ByteArray),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      false,
+			wantModified: []byte{},
+		},
+		{
+			name:         "Short array",
+			b:            new(types.ByteArray),
+			args:         args{bytes.NewReader([]byte{0x03, 0x01, 0x02, 0x03})},
+			want:         4,
+			wantErr:      false,
+			wantModified: []byte{0x01, 0x02, 0x03},
+		},
+		{
+			name:         "Truncated array",
+			b:            new(types.ByteArray),
+			args:         args{bytes.NewReader([]byte{0x03, 0x01})},
+			want:         2,
+			wantErr:      true,
+			wantModified: nil,
+		},
+		{
+			name:         "Forged length",
+			b:            new(types.ByteArray),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x07, 0x01})},
+			want:         6,
+			wantErr:      true,
+			wantModified: nil,
+		},
+		{
+			name:         "Negative length",
+			b:            new(types.ByteArray),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F})},
+			want:         5,
+			wantErr:      true,
+			wantModified: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ByteArray.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ByteArray.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual([]byte(*tt.b), tt.wantModified) {
+				t.Errorf("ByteArray.ReadFrom() modified b = %v, want %v", *tt.b, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestByteArray_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       *types.ByteArray
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Empty array",
+			b:     &types.ByteArray{},
+			want:  1,
+			wantW: []byte{0x00},
+		},
+		{
+			name:  "Short array",
+			b:     &types.ByteArray{0x01, 0x02, 0x03},
+			want:  4,
+			wantW: []byte{0x03, 0x01, 0x02, 0x03},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.b.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ByteArray.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ByteArray.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("ByteArray.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestByte_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		b            *types.Byte
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified int8
+	}{
+		{
+			name:         "Zero",
+			b:            new(types.Byte),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "Positive",
+			b:            new(types.Byte),
+			args:         args{bytes.NewReader([]byte{0x7F})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 127,
+		},
+		{
+			name:         "Negative",
+			b:            new(types.Byte),
+			args:         args{bytes.NewReader([]byte{0x80})},
+			want:         1,
+			wantErr:      false,
+			wantModified: -128,
+		},
+		{
+			name:         "Empty reader",
+			b:            new(types.Byte),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Byte.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Byte.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if int8(*tt.b) != tt.wantModified {
+				t.Errorf("Byte.ReadFrom() modified b = %v, want %v", *tt.b, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestByte_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       *types.Byte
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			b:     newByte(0),
+			want:  1,
+			wantW: []byte{0x00},
+		},
+		{
+			name:  "Positive",
+			b:     newByte(127),
+			want:  1,
+			wantW: []byte{0x7F},
+		},
+		{
+			name:  "Negative",
+			b:     newByte(-128),
+			want:  1,
+			wantW: []byte{0x80},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.b.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Byte.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Byte.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Byte.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newByte(v int8) *types.Byte {
+	bb := types.Byte(v)
+	return &bb
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+type Double float64
+
+func (d *Double) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 8)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*d = Double(math.Float64frombits(binary.BigEndian.Uint64(buffer)))
+	return int64(n), nil
+}
+
+func (d *Double) WriteTo(w io.Writer) (int64, error) {
+	buffer := make([]byte, 8)
+	binary.BigEndian.PutUint64(buffer, math.Float64bits(float64(*d)))
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestDouble_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		d            *types.Double
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified float64
+	}{
+		{
+			name:         "Zero",
+			d:            new(types.Double),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})},
+			want:         8,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "One",
+			d:            new(types.Double),
+			args:         args{bytes.NewReader([]byte{0x3F, 0xF0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})},
+			want:         8,
+			wantErr:      false,
+			wantModified: 1,
+		},
+		{
+			name:         "Negative",
+			d:            new(types.Double),
+			args:         args{bytes.NewReader([]byte{0xC0, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})},
+			want:         8,
+			wantErr:      false,
+			wantModified: -2.5,
+		},
+		{
+			name:         "Truncated data",
+			d:            new(types.Double),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})},
+			want:         7,
+			wantErr:      true,
+			wantModified: 0,
+		},
+		{
+			name:         "Empty reader",
+			d:            new(types.Double),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Double.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Double.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if float64(*tt.d) != tt.wantModified {
+				t.Errorf("Double.ReadFrom() modified d = %v, want %v", *tt.d, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestDouble_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       *types.Double
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			d:     newDouble(0),
+			want:  8,
+			wantW: []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:  "One",
+			d:     newDouble(1),
+			want:  8,
+			wantW: []byte{0x3F, 0xF0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:  "Negative",
+			d:     newDouble(-2.5),
+			want:  8,
+			wantW: []byte{0xC0, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.d.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Double.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Double.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Double.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newDouble(v float64) *types.Double {
+	dd := types.Double(v)
+	return &dd
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+type Float float32
+
+func (f *Float) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 4)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*f = Float(math.Float32frombits(binary.BigEndian.Uint32(buffer)))
+	return int64(n), nil
+}
+
+func (f *Float) WriteTo(w io.Writer) (int64, error) {
+	buffer := make([]byte, 4)
+	binary.BigEndian.PutUint32(buffer, math.Float32bits(float32(*f)))
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
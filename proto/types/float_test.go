@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestFloat_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		f            *types.Float
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified float32
+	}{
+		{
+			name:         "Zero",
+			f:            new(types.Float),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00})},
+			want:         4,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "One",
+			f:            new(types.Float),
+			args:         args{bytes.NewReader([]byte{0x3F, 0x80, 0x00, 0x00})},
+			want:         4,
+			wantErr:      false,
+			wantModified: 1,
+		},
+		{
+			name:         "Negative",
+			f:            new(types.Float),
+			args:         args{bytes.NewReader([]byte{0xC0, 0x20, 0x00, 0x00})},
+			want:         4,
+			wantErr:      false,
+			wantModified: -2.5,
+		},
+		{
+			name:         "Truncated data",
+			f:            new(types.Float),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00})},
+			want:         3,
+			wantErr:      true,
+			wantModified: 0,
+		},
+		{
+			name:         "Empty reader",
+			f:            new(types.Float),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Float.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Float.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if float32(*tt.f) != tt.wantModified {
+				t.Errorf("Float.ReadFrom() modified f = %v, want %v", *tt.f, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestFloat_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       *types.Float
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			f:     newFloat(0),
+			want:  4,
+			wantW: []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:  "One",
+			f:     newFloat(1),
+			want:  4,
+			wantW: []byte{0x3F, 0x80, 0x00, 0x00},
+		},
+		{
+			name:  "Negative",
+			f:     newFloat(-2.5),
+			want:  4,
+			wantW: []byte{0xC0, 0x20, 0x00, 0x00},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.f.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Float.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Float.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Float.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newFloat(v float32) *types.Float {
+	ff := types.Float(v)
+	return &ff
+}
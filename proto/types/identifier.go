@@ -0,0 +1,79 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+const DefaultNamespace = "minecraft"
+
+var (
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+)
+
+// Identifier is a namespaced location such as "minecraft:overworld".
+type Identifier string
+
+// ParseIdentifier validates s, adding the default namespace when s has
+// none.
+func ParseIdentifier(s string) (Identifier, error) {
+	namespace, path, ok := strings.Cut(s, ":")
+	if !ok {
+		namespace, path = DefaultNamespace, s
+	}
+	if !validIdentifierPart(namespace, false) || !validIdentifierPart(path, true) {
+		return "", ErrInvalidIdentifier
+	}
+	return Identifier(namespace + ":" + path), nil
+}
+
+func (i Identifier) Namespace() string {
+	namespace, _, ok := strings.Cut(string(i), ":")
+	if !ok {
+		return DefaultNamespace
+	}
+	return namespace
+}
+
+func (i Identifier) Path() string {
+	_, path, ok := strings.Cut(string(i), ":")
+	if !ok {
+		return string(i)
+	}
+	return path
+}
+
+func (i *Identifier) ReadFrom(r io.Reader) (int64, error) {
+	var s String
+	n, err := s.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	id, err := ParseIdentifier(string(s))
+	if err != nil {
+		return n, err
+	}
+	*i = id
+	return n, nil
+}
+
+func (i *Identifier) WriteTo(w io.Writer) (int64, error) {
+	s := String(*i)
+	return s.WriteTo(w)
+}
+
+func validIdentifierPart(s string, path bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '.', c == '-', c == '_':
+		case c == '/' && path:
+		default:
+			return false
+		}
+	}
+	return true
+}
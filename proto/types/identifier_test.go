@@ -0,0 +1,136 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestIdentifier_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		i            *types.Identifier
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified types.Identifier
+	}{
+		{
+			name:         "Namespaced",
+			i:            new(types.Identifier),
+			args:         args{bytes.NewReader(append([]byte{0x0F}, []byte("minecraft:stone")...))},
+			want:         16,
+			wantErr:      false,
+			wantModified: "minecraft:stone",
+		},
+		{
+			name:         "Default namespace",
+			i:            new(types.Identifier),
+			args:         args{bytes.NewReader(append([]byte{0x0E}, []byte("worldgen/biome")...))},
+			want:         15,
+			wantErr:      false,
+			wantModified: "minecraft:worldgen/biome",
+		},
+		{
+			name:         "Invalid characters",
+			i:            new(types.Identifier),
+			args:         args{bytes.NewReader(append([]byte{0x05}, []byte("Stone")...))},
+			want:         6,
+			wantErr:      true,
+			wantModified: "",
+		},
+		{
+			name:         "Truncated",
+			i:            new(types.Identifier),
+			args:         args{bytes.NewReader([]byte{0x05, 's'})},
+			want:         2,
+			wantErr:      true,
+			wantModified: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Identifier.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Identifier.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if *tt.i != tt.wantModified {
+				t.Errorf("Identifier.ReadFrom() modified i = %v, want %v", *tt.i, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestIdentifier_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		i       types.Identifier
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Namespaced",
+			i:     "minecraft:stone",
+			want:  16,
+			wantW: append([]byte{0x0F}, []byte("minecraft:stone")...),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.i.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Identifier.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Identifier.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Identifier.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		s             string
+		want          types.Identifier
+		wantNamespace string
+		wantPath      string
+		wantErr       bool
+	}{
+		{s: "stone", want: "minecraft:stone", wantNamespace: "minecraft", wantPath: "stone"},
+		{s: "cobble:void/plains", want: "cobble:void/plains", wantNamespace: "cobble", wantPath: "void/plains"},
+		{s: "bad/namespace:path", wantErr: true},
+		{s: "minecraft:", wantErr: true},
+		{s: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := types.ParseIdentifier(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIdentifier() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseIdentifier() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && (got.Namespace() != tt.wantNamespace || got.Path() != tt.wantPath) {
+				t.Errorf("Namespace(), Path() = %v, %v, want %v, %v", got.Namespace(), got.Path(), tt.wantNamespace, tt.wantPath)
+			}
+		})
+	}
+}
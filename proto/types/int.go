@@ -0,0 +1,25 @@
+package types
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+type Int int32
+
+func (i *Int) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 4)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*i = Int(int32(binary.BigEndian.Uint32(buffer)))
+	return int64(n), nil
+}
+
+func (i *Int) WriteTo(w io.Writer) (int64, error) {
+	buffer := make([]byte, 4)
+	binary.BigEndian.PutUint32(buffer, uint32(*i))
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestInt_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		i            *types.Int
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified int32
+	}{
+		{
+			name:         "Zero",
+			i:            new(types.Int),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00})},
+			want:         4,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "Positive",
+			i:            new(types.Int),
+			args:         args{bytes.NewReader([]byte{0x7F, 0xFF, 0xFF, 0xFF})},
+			want:         4,
+			wantErr:      false,
+			wantModified: 2147483647,
+		},
+		{
+			name:         "Negative",
+			i:            new(types.Int),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF})},
+			want:         4,
+			wantErr:      false,
+			wantModified: -1,
+		},
+		{
+			name:         "Truncated data",
+			i:            new(types.Int),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00})},
+			want:         3,
+			wantErr:      true,
+			wantModified: 0,
+		},
+		{
+			name:         "Empty reader",
+			i:            new(types.Int),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Int.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Int.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if int32(*tt.i) != tt.wantModified {
+				t.Errorf("Int.ReadFrom() modified i = %v, want %v", *tt.i, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestInt_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		i       *types.Int
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			i:     newInt(0),
+			want:  4,
+			wantW: []byte{0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:  "Positive",
+			i:     newInt(2147483647),
+			want:  4,
+			wantW: []byte{0x7F, 0xFF, 0xFF, 0xFF},
+		},
+		{
+			name:  "Negative",
+			i:     newInt(-1),
+			want:  4,
+			wantW: []byte{0xFF, 0xFF, 0xFF, 0xFF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.i.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Int.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Int.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Int.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newInt(v int32) *types.Int {
+	ii := types.Int(v)
+	return &ii
+}
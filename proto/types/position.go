@@ -0,0 +1,34 @@
+package types
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Position is a block position packed into a Long as 26 bits of X, 26
+// bits of Z and 12 bits of Y.
+type Position struct {
+	X, Y, Z int32
+}
+
+func (p *Position) ReadFrom(r io.Reader) (int64, error) {
+	var buffer [8]byte
+	n, err := io.ReadFull(r, buffer[:])
+	if err != nil {
+		return int64(n), err
+	}
+
+	v := int64(binary.BigEndian.Uint64(buffer[:]))
+	p.X = int32(v >> 38)
+	p.Y = int32(v << 52 >> 52)
+	p.Z = int32(v << 26 >> 38)
+	return int64(n), nil
+}
+
+func (p *Position) WriteTo(w io.Writer) (int64, error) {
+	var buffer [8]byte
+	v := uint64(p.X&0x3FFFFFF)<<38 | uint64(p.Z&0x3FFFFFF)<<12 | uint64(p.Y&0xFFF)
+	binary.BigEndian.PutUint64(buffer[:], v)
+	n, err := w.Write(buffer[:])
+	return int64(n), err
+}
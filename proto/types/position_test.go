@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestPosition_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified types.Position
+	}{
+		{
+			name:         "Origin",
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00})},
+			want:         8,
+			wantErr:      false,
+			wantModified: types.Position{},
+		},
+		{
+			name:         "Positive coordinates",
+			args:         args{bytes.NewReader([]byte{0x46, 0x07, 0x63, 0x2C, 0x15, 0xB4, 0x83, 0x3F})},
+			want:         8,
+			wantErr:      false,
+			wantModified: types.Position{X: 18357644, Y: 831, Z: -20882616},
+		},
+		{
+			name:         "Negative coordinates",
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})},
+			want:         8,
+			wantErr:      false,
+			wantModified: types.Position{X: -1, Y: -1, Z: -1},
+		},
+		{
+			name:         "Truncated data",
+			args:         args{bytes.NewReader([]byte{0x00, 0x00, 0x00})},
+			want:         3,
+			wantErr:      true,
+			wantModified: types.Position{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p types.Position
+			got, err := p.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Position.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Position.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(p, tt.wantModified) {
+				t.Errorf("Position.ReadFrom() p = %v, wantModified %v", p, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestPosition_WriteTo(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     types.Position
+		wantW []byte
+	}{
+		{
+			name:  "Origin",
+			p:     types.Position{},
+			wantW: []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:  "Positive coordinates",
+			p:     types.Position{X: 18357644, Y: 831, Z: -20882616},
+			wantW: []byte{0x46, 0x07, 0x63, 0x2C, 0x15, 0xB4, 0x83, 0x3F},
+		},
+		{
+			name:  "Negative coordinates",
+			p:     types.Position{X: -1, Y: -1, Z: -1},
+			wantW: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.p.WriteTo(w)
+			if err != nil {
+				t.Errorf("Position.WriteTo() error = %v", err)
+				return
+			}
+			if got != 8 {
+				t.Errorf("Position.WriteTo() = %v, want 8", got)
+			}
+			if gotW := w.Bytes(); !bytes.Equal(gotW, tt.wantW) {
+				t.Errorf("Position.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+type Short int16
+
+func (s *Short) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 2)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*s = Short(int16(binary.BigEndian.Uint16(buffer)))
+	return int64(n), nil
+}
+
+func (s *Short) WriteTo(w io.Writer) (int64, error) {
+	buffer := make([]byte, 2)
+	binary.BigEndian.PutUint16(buffer, uint16(*s))
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestShort_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		s            *types.Short
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified int16
+	}{
+		{
+			name:         "Zero",
+			s:            new(types.Short),
+			args:         args{bytes.NewReader([]byte{0x00, 0x00})},
+			want:         2,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "Positive",
+			s:            new(types.Short),
+			args:         args{bytes.NewReader([]byte{0x7F, 0xFF})},
+			want:         2,
+			wantErr:      false,
+			wantModified: 32767,
+		},
+		{
+			name:         "Negative",
+			s:            new(types.Short),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFE})},
+			want:         2,
+			wantErr:      false,
+			wantModified: -2,
+		},
+		{
+			name:         "Truncated data",
+			s:            new(types.Short),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      true,
+			wantModified: 0,
+		},
+		{
+			name:         "Empty reader",
+			s:            new(types.Short),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.s.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Short.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Short.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if int16(*tt.s) != tt.wantModified {
+				t.Errorf("Short.ReadFrom() modified s = %v, want %v", *tt.s, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestShort_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *types.Short
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			s:     newShort(0),
+			want:  2,
+			wantW: []byte{0x00, 0x00},
+		},
+		{
+			name:  "Positive",
+			s:     newShort(32767),
+			want:  2,
+			wantW: []byte{0x7F, 0xFF},
+		},
+		{
+			name:  "Negative",
+			s:     newShort(-2),
+			want:  2,
+			wantW: []byte{0xFF, 0xFE},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.s.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Short.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Short.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("Short.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newShort(v int16) *types.Short {
+	ss := types.Short(v)
+	return &ss
+}
@@ -1,10 +1,29 @@
 package types
 
-import "io"
+import (
+	"errors"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MaxStringLength is the default maximum length of a String in UTF-16 code
+// units.
+const MaxStringLength = 32767
+
+var (
+	ErrInvalidStringLength = errors.New("invalid string length")
+	ErrStringTooLong       = errors.New("string is too long")
+	ErrInvalidUTF8         = errors.New("string is not valid utf-8")
+)
 
 type String string
 
 func (s *String) ReadFrom(r io.Reader) (int64, error) {
+	return s.readFrom(r, MaxStringLength)
+}
+
+func (s *String) readFrom(r io.Reader, maxLength int) (int64, error) {
 	var totalRead int64
 	var length VarInt
 	n1, err := length.ReadFrom(r)
@@ -12,6 +31,13 @@ func (s *String) ReadFrom(r io.Reader) (int64, error) {
 	if err != nil {
 		return totalRead, err
 	}
+	if length < 0 {
+		return totalRead, ErrInvalidStringLength
+	}
+	// A UTF-16 code unit takes at most 3 bytes in UTF-8.
+	if int(length) > maxLength*3 {
+		return totalRead, ErrStringTooLong
+	}
 
 	data := make([]byte, length)
 	n2, err := io.ReadFull(r, data)
@@ -19,8 +45,15 @@ func (s *String) ReadFrom(r io.Reader) (int64, error) {
 	if err != nil {
 		return totalRead, err
 	}
+	if !utf8.Valid(data) {
+		return totalRead, ErrInvalidUTF8
+	}
+	str := String(data)
+	if UTF16Len(string(str)) > maxLength {
+		return totalRead, ErrStringTooLong
+	}
 
-	*s = String(data)
+	*s = str
 	return totalRead, nil
 }
 
@@ -43,3 +76,28 @@ func (s *String) WriteTo(w io.Writer) (int64, error) {
 
 	return totalWrite, nil
 }
+
+// LimitString returns a reader that reads into s, rejecting strings longer
+// than maxLength UTF-16 code units.
+func LimitString(s *String, maxLength int) io.ReaderFrom {
+	return limitedString{s: s, maxLength: maxLength}
+}
+
+type limitedString struct {
+	s         *String
+	maxLength int
+}
+
+func (l limitedString) ReadFrom(r io.Reader) (int64, error) {
+	return l.s.readFrom(r, l.maxLength)
+}
+
+// UTF16Len returns the number of UTF-16 code units in s, the unit in
+// which the protocol limits string lengths.
+func UTF16Len(s string) int {
+	var n int
+	for _, c := range s {
+		n += utf16.RuneLen(c)
+	}
+	return n
+}
@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"reflect"
 	"testing"
@@ -61,6 +62,30 @@ func TestString_ReadFrom(t *testing.T) {
 			wantErr:      true,
 			wantModified: "",
 		},
+		{
+			name:         "Negative length",
+			s:            new(types.String),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F})},
+			want:         5,
+			wantErr:      true,
+			wantModified: "",
+		},
+		{
+			name:         "Length over maximum",
+			s:            new(types.String),
+			args:         args{bytes.NewReader([]byte{0xFE, 0xFF, 0x05})},
+			want:         3,
+			wantErr:      true,
+			wantModified: "",
+		},
+		{
+			name:         "Invalid UTF-8",
+			s:            new(types.String),
+			args:         args{bytes.NewReader([]byte{0x02, 0xC3, 0x28})},
+			want:         3,
+			wantErr:      true,
+			wantModified: "",
+		},
 		{
 			name:         "Invalid VarInt length",
 			s:            new(types.String),
@@ -142,6 +167,58 @@ func TestString_WriteTo(t *testing.T) {
 	}
 }
 
+func TestLimitString(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		maxLength int
+		wantErr   error
+	}{
+		{name: "Within limit", data: "Notch", maxLength: 16},
+		{name: "Over limit", data: "ThisNameIsTooLong", maxLength: 16, wantErr: types.ErrStringTooLong},
+		{name: "Multibyte characters", data: "你好", maxLength: 2},
+		{name: "Surrogate pair", data: "😀", maxLength: 2},
+		{name: "Surrogate pair over limit", data: "😀", maxLength: 1, wantErr: types.ErrStringTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			if _, err := newString(tt.data).WriteTo(w); err != nil {
+				t.Fatal(err)
+			}
+			var s types.String
+			_, err := types.LimitString(&s, tt.maxLength).ReadFrom(w)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("LimitString().ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && string(s) != tt.data {
+				t.Errorf("LimitString().ReadFrom() modified s = %v, want %v", s, tt.data)
+			}
+		})
+	}
+}
+
+func TestUTF16Len(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{name: "Empty", s: "", want: 0},
+		{name: "ASCII", s: "Notch", want: 5},
+		{name: "Multibyte characters", s: "你好", want: 2},
+		{name: "Surrogate pair", s: "😀", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := types.UTF16Len(tt.s); got != tt.want {
+				t.Errorf("UTF16Len() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func newString(s string) *types.String {
 	ts := types.String(s)
 	return &ts
@@ -0,0 +1,22 @@
+package types
+
+import "io"
+
+type UnsignedByte uint8
+
+func (u *UnsignedByte) ReadFrom(r io.Reader) (int64, error) {
+	buffer := make([]byte, 1)
+	n, err := io.ReadFull(r, buffer)
+	if err != nil {
+		return int64(n), err
+	}
+	*u = UnsignedByte(buffer[0])
+	return int64(n), nil
+}
+
+func (u *UnsignedByte) WriteTo(w io.Writer) (int64, error) {
+	buffer := make([]byte, 1)
+	buffer[0] = byte(*u)
+	n, err := w.Write(buffer)
+	return int64(n), err
+}
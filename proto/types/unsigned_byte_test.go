@@ -0,0 +1,108 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestUnsignedByte_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		u            *types.UnsignedByte
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified uint8
+	}{
+		{
+			name:         "Zero",
+			u:            new(types.UnsignedByte),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "Maximum value",
+			u:            new(types.UnsignedByte),
+			args:         args{bytes.NewReader([]byte{0xFF})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 255,
+		},
+		{
+			name:         "Empty reader",
+			u:            new(types.UnsignedByte),
+			args:         args{bytes.NewReader([]byte{})},
+			want:         0,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnsignedByte.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UnsignedByte.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if uint8(*tt.u) != tt.wantModified {
+				t.Errorf("UnsignedByte.ReadFrom() modified u = %v, want %v", *tt.u, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestUnsignedByte_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       *types.UnsignedByte
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			u:     newUnsignedByte(0),
+			want:  1,
+			wantW: []byte{0x00},
+		},
+		{
+			name:  "Maximum value",
+			u:     newUnsignedByte(255),
+			want:  1,
+			wantW: []byte{0xFF},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.u.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnsignedByte.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UnsignedByte.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("UnsignedByte.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func newUnsignedByte(v uint8) *types.UnsignedByte {
+	uu := types.UnsignedByte(v)
+	return &uu
+}
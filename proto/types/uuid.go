@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	ErrInvalidUUID = errors.New("invalid uuid")
+)
+
+type UUID [16]byte
+
+func (u *UUID) ReadFrom(r io.Reader) (int64, error) {
+	var buffer [16]byte
+	n, err := io.ReadFull(r, buffer[:])
+	if err != nil {
+		return int64(n), err
+	}
+	*u = buffer
+	return int64(n), nil
+}
+
+func (u *UUID) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(u[:])
+	return int64(n), err
+}
+
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *UUID) UnmarshalText(text []byte) error {
+	s := strings.ReplaceAll(string(text), "-", "")
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(u) {
+		return ErrInvalidUUID
+	}
+	copy(u[:], b)
+	return nil
+}
@@ -0,0 +1,131 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+var testUUIDBytes = []byte{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5}
+
+func TestUUID_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		u            *types.UUID
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified types.UUID
+	}{
+		{
+			name:         "Valid UUID",
+			u:            new(types.UUID),
+			args:         args{bytes.NewReader(testUUIDBytes)},
+			want:         16,
+			wantErr:      false,
+			wantModified: types.UUID(testUUIDBytes),
+		},
+		{
+			name:         "Truncated UUID",
+			u:            new(types.UUID),
+			args:         args{bytes.NewReader(testUUIDBytes[:10])},
+			want:         10,
+			wantErr:      true,
+			wantModified: types.UUID{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.u.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UUID.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UUID.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if *tt.u != tt.wantModified {
+				t.Errorf("UUID.ReadFrom() modified u = %v, want %v", *tt.u, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestUUID_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		u       *types.UUID
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero UUID",
+			u:     &types.UUID{},
+			want:  16,
+			wantW: make([]byte, 16),
+		},
+		{
+			name:  "Valid UUID",
+			u:     (*types.UUID)(testUUIDBytes),
+			want:  16,
+			wantW: testUUIDBytes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.u.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UUID.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UUID.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("UUID.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestUUID_String(t *testing.T) {
+	u := types.UUID(testUUIDBytes)
+	if got, want := u.String(), "069a79f4-44e9-4726-a5be-fca90e38aaf5"; got != want {
+		t.Errorf("UUID.String() = %v, want %v", got, want)
+	}
+}
+
+func TestUUID_UnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    types.UUID
+		wantErr bool
+	}{
+		{name: "Dashed", text: "069a79f4-44e9-4726-a5be-fca90e38aaf5", want: types.UUID(testUUIDBytes)},
+		{name: "Undashed", text: "069a79f444e94726a5befca90e38aaf5", want: types.UUID(testUUIDBytes)},
+		{name: "Too short", text: "069a79f4", wantErr: true},
+		{name: "Not hex", text: "not-a-uuid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u types.UUID
+			err := u.UnmarshalText([]byte(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UUID.UnmarshalText() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if u != tt.want {
+				t.Errorf("UUID.UnmarshalText() = %v, want %v", u, tt.want)
+			}
+		})
+	}
+}
@@ -1,45 +1,65 @@
 package types
 
-import "io"
+import (
+	"errors"
+	"io"
+)
+
+const MaxVarIntLen = 5
+
+var (
+	ErrVarIntTooBig = errors.New("varint is too big")
+)
 
 type VarInt int32
 
 func (v *VarInt) ReadFrom(r io.Reader) (int64, error) {
 	var totalRead int64
-	var result int32
-	var shift uint
+	var result uint32
+	var b [1]byte
 	for {
-		b := make([]byte, 1)
-		if _, err := r.Read(b); err != nil {
+		if totalRead == MaxVarIntLen {
+			return totalRead, ErrVarIntTooBig
+		}
+		if _, err := io.ReadFull(r, b[:]); err != nil {
 			return totalRead, err
 		}
+		result |= uint32(b[0]&0b01111111) << (7 * totalRead)
 		totalRead++
-		result |= int32(b[0]&0b01111111) << shift
 		if b[0]&0b10000000 == 0 {
 			break
 		}
-		shift += 7
 	}
 
 	*v = VarInt(result)
 	return totalRead, nil
 }
 
-func (v *VarInt) WriteTo(r io.Writer) (int64, error) {
-	value := *v
-	var p []byte
+func (v *VarInt) WriteTo(w io.Writer) (int64, error) {
+	value := uint32(*v)
+	var p [MaxVarIntLen]byte
+	var i int
 	for {
-		temp := byte(value & 0b01111111)
+		p[i] = byte(value & 0b01111111)
 		value >>= 7
-		if value != 0 {
-			temp |= 0b10000000
-		}
-		p = append(p, temp)
 		if value == 0 {
 			break
 		}
+		p[i] |= 0b10000000
+		i++
 	}
 
-	n, err := r.Write(p)
+	n, err := w.Write(p[:i+1])
 	return int64(n), err
 }
+
+// Len returns the number of bytes WriteTo writes for v.
+func (v VarInt) Len() int {
+	value := uint32(v)
+	n := 1
+	for value >= 0b10000000 {
+		value >>= 7
+		n++
+	}
+	return n
+}
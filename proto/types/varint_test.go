@@ -53,6 +53,30 @@ func TestVarInt_ReadFrom(t *testing.T) {
 			wantErr:      false,
 			wantModified: 2147483647,
 		},
+		{
+			name:         "Negative one",
+			v:            new(types.VarInt),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F})},
+			want:         5,
+			wantErr:      false,
+			wantModified: -1,
+		},
+		{
+			name:         "Minimum negative number",
+			v:            new(types.VarInt),
+			args:         args{bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x80, 0x08})},
+			want:         5,
+			wantErr:      false,
+			wantModified: -2147483648,
+		},
+		{
+			name:         "Too big",
+			v:            new(types.VarInt),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01})},
+			want:         5,
+			wantErr:      true,
+			wantModified: 0,
+		},
 		{
 			name:         "Truncated VarInt",
 			v:            new(types.VarInt),
@@ -115,6 +139,20 @@ func TestVarInt_WriteTo(t *testing.T) {
 			wantR:   []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x07},
 			wantErr: false,
 		},
+		{
+			name:    "Negative one",
+			v:       newVarInt(-1),
+			want:    5,
+			wantR:   []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F},
+			wantErr: false,
+		},
+		{
+			name:    "Minimum negative number",
+			v:       newVarInt(-2147483648),
+			want:    5,
+			wantR:   []byte{0x80, 0x80, 0x80, 0x80, 0x08},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -134,6 +172,49 @@ func TestVarInt_WriteTo(t *testing.T) {
 	}
 }
 
+func TestVarInt_Len(t *testing.T) {
+	tests := []struct {
+		v    types.VarInt
+		want int
+	}{
+		{v: 0, want: 1},
+		{v: 127, want: 1},
+		{v: 128, want: 2},
+		{v: 2097151, want: 3},
+		{v: 2147483647, want: 5},
+		{v: -1, want: 5},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Len(); got != tt.want {
+			t.Errorf("VarInt(%d).Len() = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func FuzzVarInt(f *testing.F) {
+	for _, i := range []int32{0, 1, 300, -1, 2147483647, -2147483648} {
+		f.Add(i)
+	}
+	f.Fuzz(func(t *testing.T, i int32) {
+		v := types.VarInt(i)
+		buf := &bytes.Buffer{}
+		n, err := v.WriteTo(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int(n) != v.Len() {
+			t.Errorf("VarInt.WriteTo() = %v, want Len() %v", n, v.Len())
+		}
+		var got types.VarInt
+		if _, err := got.ReadFrom(buf); err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("VarInt round trip = %v, want %v", got, v)
+		}
+	})
+}
+
 func newVarInt(i int32) *types.VarInt {
 	v := types.VarInt(i)
 	return &v
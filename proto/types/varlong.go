@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+	"io"
+)
+
+const MaxVarLongLen = 10
+
+var (
+	ErrVarLongTooBig = errors.New("varlong is too big")
+)
+
+type VarLong int64
+
+func (v *VarLong) ReadFrom(r io.Reader) (int64, error) {
+	var totalRead int64
+	var result uint64
+	var b [1]byte
+	for {
+		if totalRead == MaxVarLongLen {
+			return totalRead, ErrVarLongTooBig
+		}
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return totalRead, err
+		}
+		result |= uint64(b[0]&0b01111111) << (7 * totalRead)
+		totalRead++
+		if b[0]&0b10000000 == 0 {
+			break
+		}
+	}
+
+	*v = VarLong(result)
+	return totalRead, nil
+}
+
+func (v *VarLong) WriteTo(w io.Writer) (int64, error) {
+	value := uint64(*v)
+	var p [MaxVarLongLen]byte
+	var i int
+	for {
+		p[i] = byte(value & 0b01111111)
+		value >>= 7
+		if value == 0 {
+			break
+		}
+		p[i] |= 0b10000000
+		i++
+	}
+
+	n, err := w.Write(p[:i+1])
+	return int64(n), err
+}
+
+// Len returns the number of bytes WriteTo writes for v.
+func (v VarLong) Len() int {
+	value := uint64(v)
+	n := 1
+	for value >= 0b10000000 {
+		value >>= 7
+		n++
+	}
+	return n
+}
@@ -0,0 +1,200 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func TestVarLong_ReadFrom(t *testing.T) {
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name         string
+		v            *types.VarLong
+		args         args
+		want         int64
+		wantErr      bool
+		wantModified int64
+	}{
+		{
+			name:         "Zero",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0x00})},
+			want:         1,
+			wantErr:      false,
+			wantModified: 0,
+		},
+		{
+			name:         "Medium positive number",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0xAC, 0x02})},
+			want:         2,
+			wantErr:      false,
+			wantModified: 300,
+		},
+		{
+			name:         "Large positive number",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F})},
+			want:         9,
+			wantErr:      false,
+			wantModified: 9223372036854775807,
+		},
+		{
+			name:         "Negative one",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01})},
+			want:         10,
+			wantErr:      false,
+			wantModified: -1,
+		},
+		{
+			name:         "Minimum negative number",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01})},
+			want:         10,
+			wantErr:      false,
+			wantModified: -9223372036854775808,
+		},
+		{
+			name:         "Too big",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01})},
+			want:         10,
+			wantErr:      true,
+			wantModified: 0,
+		},
+		{
+			name:         "Truncated VarLong",
+			v:            new(types.VarLong),
+			args:         args{bytes.NewReader([]byte{0xFF, 0xFF})},
+			want:         2,
+			wantErr:      true,
+			wantModified: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.ReadFrom(tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VarLong.ReadFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("VarLong.ReadFrom() = %v, want %v", got, tt.want)
+			}
+			if int64(*tt.v) != tt.wantModified {
+				t.Errorf("VarLong.ReadFrom() modified v = %v, want %v", *tt.v, tt.wantModified)
+			}
+		})
+	}
+}
+
+func TestVarLong_WriteTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       *types.VarLong
+		want    int64
+		wantW   []byte
+		wantErr bool
+	}{
+		{
+			name:  "Zero",
+			v:     newVarLong(0),
+			want:  1,
+			wantW: []byte{0x00},
+		},
+		{
+			name:  "Medium positive number",
+			v:     newVarLong(300),
+			want:  2,
+			wantW: []byte{0xAC, 0x02},
+		},
+		{
+			name:  "Large positive number",
+			v:     newVarLong(9223372036854775807),
+			want:  9,
+			wantW: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F},
+		},
+		{
+			name:  "Negative one",
+			v:     newVarLong(-1),
+			want:  10,
+			wantW: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01},
+		},
+		{
+			name:  "Minimum negative number",
+			v:     newVarLong(-9223372036854775808),
+			want:  10,
+			wantW: []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			got, err := tt.v.WriteTo(w)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VarLong.WriteTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("VarLong.WriteTo() = %v, want %v", got, tt.want)
+			}
+			if gotW := w.Bytes(); !reflect.DeepEqual(gotW, tt.wantW) {
+				t.Errorf("VarLong.WriteTo() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestVarLong_Len(t *testing.T) {
+	tests := []struct {
+		v    types.VarLong
+		want int
+	}{
+		{v: 0, want: 1},
+		{v: 128, want: 2},
+		{v: 2147483647, want: 5},
+		{v: 9223372036854775807, want: 9},
+		{v: -1, want: 10},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Len(); got != tt.want {
+			t.Errorf("VarLong(%d).Len() = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func FuzzVarLong(f *testing.F) {
+	for _, i := range []int64{0, 1, 300, -1, 9223372036854775807, -9223372036854775808} {
+		f.Add(i)
+	}
+	f.Fuzz(func(t *testing.T, i int64) {
+		v := types.VarLong(i)
+		buf := &bytes.Buffer{}
+		n, err := v.WriteTo(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int(n) != v.Len() {
+			t.Errorf("VarLong.WriteTo() = %v, want Len() %v", n, v.Len())
+		}
+		var got types.VarLong
+		if _, err := got.ReadFrom(buf); err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("VarLong round trip = %v, want %v", got, v)
+		}
+	})
+}
+
+func newVarLong(i int64) *types.VarLong {
+	v := types.VarLong(i)
+	return &v
+}
@@ -0,0 +1,36 @@
+package proto
+
+// Version is a protocol version and the name of the latest game release
+// that speaks it.
+type Version struct {
+	Protocol int32
+	Name     string
+}
+
+// Versions lists the protocol versions registered in DefaultRegistry,
+// oldest first.
+var Versions = []Version{
+	{Protocol: 768, Name: "1.21.3"},
+	{Protocol: 769, Name: "1.21.4"},
+}
+
+// LatestVersion is the newest entry of Versions.
+var LatestVersion = Versions[len(Versions)-1]
+
+// LookupVersion returns the entry of Versions for a protocol number.
+func LookupVersion(protocol int32) (Version, bool) {
+	for _, v := range Versions {
+		if v.Protocol == protocol {
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+// VersionRange names the supported releases, such as "1.21.3-1.21.4".
+func VersionRange() string {
+	if len(Versions) == 1 {
+		return Versions[0].Name
+	}
+	return Versions[0].Name + "-" + LatestVersion.Name
+}
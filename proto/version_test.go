@@ -0,0 +1,34 @@
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+)
+
+func TestLookupVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol int32
+		want     proto.Version
+		wantOK   bool
+	}{
+		{name: "1.21.3", protocol: 768, want: proto.Version{Protocol: 768, Name: "1.21.3"}, wantOK: true},
+		{name: "1.21.4", protocol: 769, want: proto.Version{Protocol: 769, Name: "1.21.4"}, wantOK: true},
+		{name: "Unsupported", protocol: 47, want: proto.Version{}, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := proto.LookupVersion(tt.protocol)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("LookupVersion() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	if got, want := proto.VersionRange(), "1.21.3-1.21.4"; got != want {
+		t.Errorf("VersionRange() = %v, want %v", got, want)
+	}
+}
@@ -1,103 +1,368 @@
 package cobble
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"io"
 	"log"
 	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/nonya123456/cobble/auth"
 	"github.com/nonya123456/cobble/proto"
-	"github.com/nonya123456/cobble/proto/handshaking"
 	"github.com/nonya123456/cobble/proto/status"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+const (
+	defaultShutdownMessage = "Server closed"
+	defaultLoginTimeout    = 30 * time.Second
+	defaultWriteTimeout    = 10 * time.Second
+)
+
+// defaultIdleTimeouts are short before login, where clients send their
+// packets right away, and match vanilla afterwards. Clients past login
+// answer keep-alives well within them.
+var defaultIdleTimeouts = map[proto.State]time.Duration{
+	proto.StateHandshaking:   5 * time.Second,
+	proto.StateStatus:        5 * time.Second,
+	proto.StateLogin:         30 * time.Second,
+	proto.StateConfiguration: 30 * time.Second,
+	proto.StatePlay:          30 * time.Second,
+}
+
+var (
+	ErrServerClosed = errors.New("cobble: Server closed")
 )
 
 type Server struct {
 	Addr string
+
+	// OnlineMode authenticates players against SessionServer, which
+	// defaults to auth.DefaultSessionServer when empty.
+	OnlineMode    bool
+	SessionServer string
+
+	// AcceptTransfers allows clients sent here by another server's
+	// Transfer packet to log in. Such clients are disconnected when it is
+	// false.
+	AcceptTransfers bool
+
+	// CompressionThreshold is the packet size from which packets are
+	// compressed after login. Zero disables compression.
+	CompressionThreshold int
+
+	// MaxPacketLength is the largest packet frame accepted from clients.
+	// It defaults to proto.DefaultMaxPacketLength.
+	MaxPacketLength int
+
+	// StatusProvider computes the server list response for each status
+	// request. A zero Version in its result is filled in by the server.
+	StatusProvider func(c *Conn) status.Response
+
+	// Favicon is a data URI from LoadFavicon or EncodeFavicon, sent when
+	// the status response does not set its own.
+	Favicon string
+
+	// ShutdownMessage is the disconnect reason sent to players by
+	// Shutdown. It defaults to "Server closed".
+	ShutdownMessage string
+
+	// Registry maps packet IDs to messages for each protocol version. It
+	// defaults to proto.DefaultRegistry.
+	Registry *proto.Registry
+
+	// RegistryData returns the registries and tags sent during
+	// configuration to clients of a protocol version. It is called for
+	// every client and defaults to vanilla.Load; wrap vanilla.Load to add
+	// or override entries.
+	RegistryData func(protocol int32) (*vanilla.Data, error)
+
+	// IdleTimeouts bound how long a client may go without sending a packet
+	// in each state. States without an entry default to 5 seconds before
+	// login and 30 seconds from then on. A negative duration disables the
+	// timeout.
+	IdleTimeouts map[proto.State]time.Duration
+
+	// LoginTimeout bounds the time from accepting a connection to the end
+	// of login, and the lifetime of status connections, so that a client
+	// cannot hold a connection by sending slowly. It defaults to 30
+	// seconds; a negative duration disables it.
+	LoginTimeout time.Duration
+
+	// WriteTimeout bounds each packet write, so that a client that stops
+	// reading cannot block the server. It defaults to 10 seconds; a
+	// negative duration disables it.
+	WriteTimeout time.Duration
+
+	// NotFound handles packets without a registered or built-in handler.
+	// When nil, such packets are logged and ignored.
+	NotFound Handler
+
+	mu         sync.RWMutex
+	handlers   map[handlerKey]Handler
+	middleware []Middleware
+	listeners  map[net.Listener]struct{}
+	conns      map[*Conn]struct{}
+	connWG     sync.WaitGroup
+	inShutdown atomic.Bool
+
+	keyOnce sync.Once
+	key     *auth.KeyPair
+	keyErr  error
+
+	// keepAliveInterval and keepAliveTimeout override the vanilla
+	// durations in tests.
+	keepAliveInterval time.Duration
+	keepAliveTimeout  time.Duration
 }
 
-func (s Server) Run() error {
+// Run listens on Addr and serves connections until the server is shut
+// down.
+func (s *Server) Run() error {
 	l, err := net.Listen("tcp", s.Addr)
 	if err != nil {
 		return err
 	}
-	defer l.Close()
-
 	log.Printf("Server listening on %s\n", s.Addr)
+	return s.Serve(context.Background(), l)
+}
+
+// Serve accepts connections on l until Shutdown is called or ctx is
+// canceled, which shuts the server down without waiting for connections.
+// It always returns a non-nil error, ErrServerClosed after a shutdown.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	if !s.trackListener(l) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrackListener(l)
 
+	stop := context.AfterFunc(ctx, func() { s.close() })
+	defer stop()
+
+	var delay time.Duration
 	for {
 		conn, err := l.Accept()
 		if err != nil {
-			log.Printf("Failed to accept connection: %v\n", err)
+			if s.inShutdown.Load() {
+				return ErrServerClosed
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			if delay == 0 {
+				delay = 5 * time.Millisecond
+			} else if delay *= 2; delay > time.Second {
+				delay = time.Second
+			}
+			log.Printf("Failed to accept connection: %v; retrying in %v\n", err, delay)
+			time.Sleep(delay)
 			continue
 		}
-
+		delay = 0
 		go s.handle(conn)
 	}
 }
 
-func (s Server) handle(conn net.Conn) {
-	defer conn.Close()
-
-	state := 0
-	for {
-		p, err := proto.ReadPacket(conn)
-		if err != nil {
-			if err == io.EOF || err.Error() == "unexpected EOF" {
-				log.Printf("Client %s disconnected\n", conn.RemoteAddr())
-				return
-			}
+// Shutdown stops accepting connections, disconnects players with
+// ShutdownMessage, closes every other connection and waits for their
+// handlers to return. If ctx expires first, the remaining connections are
+// closed forcibly and Shutdown returns the context's error.
+func (s *Server) Shutdown(ctx context.Context) error {
+	conns := s.close()
 
-			log.Printf("Error reading packet from %s: %v\n", conn.RemoteAddr(), err)
+	done := make(chan struct{})
+	go func() {
+		s.connWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, c := range conns {
+			c.Close()
 		}
+		return ctx.Err()
+	}
+}
 
-		r := bytes.NewReader(p.Data)
+func (s *Server) close() []*Conn {
+	s.inShutdown.Store(true)
 
-		switch state {
-		case 0:
-			switch p.ID {
-			case handshaking.HandshakeID:
-				var handshake handshaking.Handshake
-				if _, err := handshake.ReadFrom(r); err != nil {
-					log.Printf("Failed to read handshake: %v\n", err)
-					continue
-				}
+	s.mu.Lock()
+	for l := range s.listeners {
+		l.Close()
+	}
+	conns := make([]*Conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
 
-				state = int(handshake.NextState)
-			default:
-				log.Printf("Received unknown packet %v\n", p.ID)
-			}
-		case 1:
-			switch p.ID {
-			case status.StatusRequestID:
-				var req status.StatusRequest
-				if _, err := req.ReadFrom(r); err != nil {
-					log.Printf("Failed to read status request: %v\n", err)
-					continue
-				}
+	reason := s.ShutdownMessage
+	if reason == "" {
+		reason = defaultShutdownMessage
+	}
+	for _, c := range conns {
+		go func() {
+			c.Disconnect(text.Plain(reason))
+			c.Close()
+		}()
+	}
+	return conns
+}
 
-				res := status.StatusResponse{JSONResponse: `{"version":{"name":"1.23.1","protocol": 768}}`}
-				if err := proto.WritePacket(conn, status.StatusResponseID, &res); err != nil {
-					log.Printf("Failed to write status response: %v\n", err)
-				}
+func (s *Server) trackListener(l net.Listener) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inShutdown.Load() {
+		return false
+	}
+	if s.listeners == nil {
+		s.listeners = make(map[net.Listener]struct{})
+	}
+	s.listeners[l] = struct{}{}
+	return true
+}
 
-			case status.PingRequestID:
-				var req status.PingRequest
-				if _, err := req.ReadFrom(r); err != nil {
-					log.Printf("Failed to read ping request: %v\n", err)
-					continue
-				}
+func (s *Server) untrackListener(l net.Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, l)
+}
+
+func (s *Server) trackConn(c *Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inShutdown.Load() {
+		return false
+	}
+	if s.conns == nil {
+		s.conns = make(map[*Conn]struct{})
+	}
+	s.conns[c] = struct{}{}
+	s.connWG.Add(1)
+	return true
+}
 
-				payload := req.Payload
-				res := status.PingResponse{Payload: payload}
-				if err := proto.WritePacket(conn, status.PingResponseID, &res); err != nil {
-					log.Printf("Failed to write ping response: %v\n", err)
+func (s *Server) untrackConn(c *Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, c)
+	s.connWG.Done()
+}
+
+func (s *Server) keyPair() (*auth.KeyPair, error) {
+	s.keyOnce.Do(func() {
+		s.key, s.keyErr = auth.GenerateKeyPair()
+	})
+	return s.key, s.keyErr
+}
+
+func (s *Server) sessionServer() string {
+	if s.SessionServer == "" {
+		return auth.DefaultSessionServer
+	}
+	return s.SessionServer
+}
+
+func (s *Server) registry() *proto.Registry {
+	if s.Registry == nil {
+		return proto.DefaultRegistry
+	}
+	return s.Registry
+}
+
+func (s *Server) registryData(protocol int32) (*vanilla.Data, error) {
+	if s.RegistryData == nil {
+		return vanilla.Load(protocol)
+	}
+	return s.RegistryData(protocol)
+}
+
+func (s *Server) idleTimeout(state proto.State) time.Duration {
+	if d, ok := s.IdleTimeouts[state]; ok && d != 0 {
+		return d
+	}
+	return defaultIdleTimeouts[state]
+}
+
+func (s *Server) loginTimeout() time.Duration {
+	if s.LoginTimeout == 0 {
+		return defaultLoginTimeout
+	}
+	return s.LoginTimeout
+}
+
+func (s *Server) writeTimeout() time.Duration {
+	if s.WriteTimeout == 0 {
+		return defaultWriteTimeout
+	}
+	return s.WriteTimeout
+}
+
+func (s *Server) keepAliveDurations() (interval, timeout time.Duration) {
+	interval, timeout = keepAliveInterval, keepAliveTimeout
+	if s.keepAliveInterval > 0 {
+		interval = s.keepAliveInterval
+	}
+	if s.keepAliveTimeout > 0 {
+		timeout = s.keepAliveTimeout
+	}
+	return interval, timeout
+}
+
+func (s *Server) handle(conn net.Conn) {
+	c := newConn(s, conn)
+	defer c.Close()
+	defer close(c.done)
+	if !s.trackConn(c) {
+		return
+	}
+	defer s.untrackConn(c)
+
+	var loginDeadline time.Time
+	if d := s.loginTimeout(); d > 0 {
+		loginDeadline = time.Now().Add(d)
+	}
+	c.setReadDeadline(loginDeadline)
+	if isLegacyPing(c) {
+		if err := handleLegacyPing(c); err != nil {
+			log.Printf("Failed to answer legacy ping from %s: %v\n", c.RemoteAddr(), err)
+		}
+		return
+	}
+	for {
+		c.setReadDeadline(loginDeadline)
+		p, err := c.ReadPacket()
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+				log.Printf("Client %s disconnected\n", c.RemoteAddr())
+			case errors.Is(err, os.ErrDeadlineExceeded):
+				log.Printf("Client %s timed out in %v state\n", c.RemoteAddr(), c.State())
+				if c.State() == proto.StateLogin && !loginDeadline.IsZero() && !time.Now().Before(loginDeadline) {
+					c.Disconnect(text.Translatable("multiplayer.disconnect.slow_login"))
+				} else {
+					c.Disconnect(text.Translatable("disconnect.timeout"))
 				}
+			case errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrClosedPipe):
 			default:
-				log.Printf("Received unknown packet %v\n", p.ID)
+				// The stream cannot be resynchronised after a bad frame.
+				log.Printf("Closing connection from %s: %v\n", c.RemoteAddr(), err)
 			}
+			return
+		}
 
-		default:
-			log.Printf("Unimplemented state\n")
+		if err := s.handler(c, p.ID).ServePacket(c, p); err != nil {
+			log.Printf("Closing connection from %s: %v\n", c.RemoteAddr(), err)
+			return
 		}
 	}
 }
@@ -0,0 +1,348 @@
+package cobble
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/text"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+func dialTest(t *testing.T, s *Server) *proto.Conn {
+	t.Helper()
+	client, conn := net.Pipe()
+	go s.handle(conn)
+	t.Cleanup(func() { client.Close() })
+	return proto.NewConn(client)
+}
+
+func writeTestPacket(t *testing.T, c *proto.Conn, id int32, p io.WriterTo) {
+	t.Helper()
+	if err := c.WritePacket(id, p); err != nil {
+		t.Fatalf("Conn.WritePacket() error = %v", err)
+	}
+}
+
+func readTestPacket(t *testing.T, c *proto.Conn, id int32, p io.ReaderFrom) {
+	t.Helper()
+	packet, err := c.ReadPacket()
+	if err != nil {
+		t.Fatalf("Conn.ReadPacket() error = %v", err)
+	}
+	if packet.ID != id {
+		t.Fatalf("Conn.ReadPacket() id = %#x, want %#x", packet.ID, id)
+	}
+	if _, err := p.ReadFrom(bytes.NewReader(packet.Data)); err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+}
+
+var handshakeLogin = handshaking.Handshake{ProtocolVersion: 768, ServerAddress: "localhost", ServerPort: 25565, NextState: 2}
+
+func startLogin(t *testing.T, c *proto.Conn, name string) {
+	t.Helper()
+	writeTestPacket(t, c, handshaking.HandshakeID, &handshakeLogin)
+	writeTestPacket(t, c, login.LoginStartID, &login.LoginStart{Name: name})
+}
+
+func TestServer_offlineLogin(t *testing.T) {
+	c := dialTest(t, &Server{})
+	startLogin(t, c, "Notch")
+
+	var res login.LoginSuccess
+	readTestPacket(t, c, login.LoginSuccessID, &res)
+	want := login.LoginSuccess{UUID: login.OfflineUUID("Notch"), Username: "Notch"}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("LoginSuccess = %v, want %v", res, want)
+	}
+}
+
+func TestServer_compressedLogin(t *testing.T) {
+	c := dialTest(t, &Server{CompressionThreshold: 16})
+	startLogin(t, c, "Notch")
+
+	var req login.SetCompression
+	readTestPacket(t, c, login.SetCompressionID, &req)
+	if req.Threshold != 16 {
+		t.Errorf("SetCompression.Threshold = %v, want 16", req.Threshold)
+	}
+
+	c.SetCompressionThreshold(int(req.Threshold))
+	var res login.LoginSuccess
+	readTestPacket(t, c, login.LoginSuccessID, &res)
+	if res.Username != "Notch" {
+		t.Errorf("LoginSuccess.Username = %v, want Notch", res.Username)
+	}
+}
+
+func TestServer_invalidUsername(t *testing.T) {
+	c := dialTest(t, &Server{})
+	startLogin(t, c, "not a valid name")
+
+	var res login.Disconnect
+	readTestPacket(t, c, login.DisconnectID, &res)
+	if want := text.Plain("Invalid username"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+}
+
+func TestServer_protocolVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		protocol   int32
+		wantReason *text.Component
+	}{
+		{name: "1.21.3", protocol: 768},
+		{name: "1.21.4", protocol: 769},
+		{name: "Older client", protocol: 767, wantReason: &text.Component{
+			Translate: "multiplayer.disconnect.outdated_client",
+			With:      []text.Component{text.Plain("1.21.3-1.21.4")},
+		}},
+		{name: "Newer client", protocol: 770, wantReason: &text.Component{
+			Translate: "multiplayer.disconnect.outdated_server",
+			With:      []text.Component{text.Plain("1.21.3-1.21.4")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{})
+			handshake := handshakeLogin
+			handshake.ProtocolVersion = tt.protocol
+			writeTestPacket(t, c, handshaking.HandshakeID, &handshake)
+
+			if tt.wantReason != nil {
+				var res login.Disconnect
+				readTestPacket(t, c, login.DisconnectID, &res)
+				if !reflect.DeepEqual(res.Reason, *tt.wantReason) {
+					t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, *tt.wantReason)
+				}
+				return
+			}
+			writeTestPacket(t, c, login.LoginStartID, &login.LoginStart{Name: "Notch"})
+			var res login.LoginSuccess
+			readTestPacket(t, c, login.LoginSuccessID, &res)
+			if res.Username != "Notch" {
+				t.Errorf("LoginSuccess.Username = %v, want Notch", res.Username)
+			}
+		})
+	}
+}
+
+func TestServer_badFrame(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame []byte
+	}{
+		{name: "Packet too large", frame: []byte{0x80, 0x01}},
+		{name: "Negative length", frame: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x0F}},
+		{name: "VarInt too big", frame: []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{MaxPacketLength: 64})
+			writeTestPacket(t, c, handshaking.HandshakeID, &handshakeLogin)
+			if _, err := c.Write(tt.frame); err != nil {
+				t.Fatalf("Conn.Write() error = %v", err)
+			}
+			if _, err := c.ReadPacket(); err != io.EOF {
+				t.Errorf("Conn.ReadPacket() error = %v, want %v", err, io.EOF)
+			}
+		})
+	}
+}
+
+func TestServer_onlineLogin(t *testing.T) {
+	var gotServerID string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotServerID = r.URL.Query().Get("serverId")
+		if r.URL.Query().Get("username") != "Notch" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"id":"069a79f444e94726a5befca90e38aaf5","name":"Notch","properties":[{"name":"textures","value":"e30=","signature":"c2ln"}]}`))
+	}))
+	defer ts.Close()
+
+	s := &Server{OnlineMode: true, SessionServer: ts.URL}
+	c := dialTest(t, s)
+	startLogin(t, c, "Notch")
+
+	var req login.EncryptionRequest
+	readTestPacket(t, c, login.EncryptionRequestID, &req)
+	if !req.ShouldAuthenticate {
+		t.Errorf("EncryptionRequest.ShouldAuthenticate = false, want true")
+	}
+
+	sharedSecret, encryptionRes := encryptionResponse(t, req)
+	writeTestPacket(t, c, login.EncryptionResponseID, encryptionRes)
+
+	if err := c.EnableEncryption(sharedSecret); err != nil {
+		t.Fatalf("Conn.EnableEncryption() error = %v", err)
+	}
+	var res login.LoginSuccess
+	readTestPacket(t, c, login.LoginSuccessID, &res)
+	want := login.LoginSuccess{
+		UUID:       types.UUID{0x06, 0x9a, 0x79, 0xf4, 0x44, 0xe9, 0x47, 0x26, 0xa5, 0xbe, 0xfc, 0xa9, 0x0e, 0x38, 0xaa, 0xf5},
+		Username:   "Notch",
+		Properties: []login.Property{{Name: "textures", Value: "e30=", Signature: "c2ln"}},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("LoginSuccess = %v, want %v", res, want)
+	}
+	if gotServerID == "" {
+		t.Errorf("session server was not queried")
+	}
+}
+
+func TestServer_onlineLoginRejected(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	s := &Server{OnlineMode: true, SessionServer: ts.URL}
+	c := dialTest(t, s)
+	startLogin(t, c, "Notch")
+
+	var req login.EncryptionRequest
+	readTestPacket(t, c, login.EncryptionRequestID, &req)
+	sharedSecret, encryptionRes := encryptionResponse(t, req)
+	writeTestPacket(t, c, login.EncryptionResponseID, encryptionRes)
+
+	if err := c.EnableEncryption(sharedSecret); err != nil {
+		t.Fatalf("Conn.EnableEncryption() error = %v", err)
+	}
+	var res login.Disconnect
+	readTestPacket(t, c, login.DisconnectID, &res)
+	if want := text.Plain("Failed to verify username!"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+}
+
+func encryptionResponse(t *testing.T, req login.EncryptionRequest) ([]byte, *login.EncryptionResponse) {
+	t.Helper()
+	key, err := x509.ParsePKIXPublicKey(req.PublicKey)
+	if err != nil {
+		t.Fatalf("ParsePKIXPublicKey() error = %v", err)
+	}
+	sharedSecret := make([]byte, 16)
+	rand.Read(sharedSecret)
+	encryptedSecret, _ := rsa.EncryptPKCS1v15(rand.Reader, key.(*rsa.PublicKey), sharedSecret)
+	encryptedToken, _ := rsa.EncryptPKCS1v15(rand.Reader, key.(*rsa.PublicKey), req.VerifyToken)
+	return sharedSecret, &login.EncryptionResponse{SharedSecret: encryptedSecret, VerifyToken: encryptedToken}
+}
+
+func TestServer_loginOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		packets func(t *testing.T, c *proto.Conn)
+	}{
+		{name: "Acknowledged before Login Start", packets: func(t *testing.T, c *proto.Conn) {
+			writeTestPacket(t, c, handshaking.HandshakeID, &handshakeLogin)
+			writeTestPacket(t, c, login.LoginAcknowledgedID, &login.LoginAcknowledged{})
+		}},
+		{name: "Repeated Login Start", packets: func(t *testing.T, c *proto.Conn) {
+			startLogin(t, c, "Notch")
+			readTestPacket(t, c, login.LoginSuccessID, &login.LoginSuccess{})
+			writeTestPacket(t, c, login.LoginStartID, &login.LoginStart{Name: "jeb_"})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{})
+			tt.packets(t, c)
+			if _, err := c.ReadPacket(); err != io.EOF {
+				t.Errorf("Conn.ReadPacket() error = %v, want %v", err, io.EOF)
+			}
+		})
+	}
+}
+
+func TestServer_onlineLoginOrder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"069a79f444e94726a5befca90e38aaf5","name":"Notch"}`))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name    string
+		packets func(t *testing.T, c *proto.Conn)
+	}{
+		{name: "Acknowledged before Encryption Response", packets: func(t *testing.T, c *proto.Conn) {
+			startLogin(t, c, "Notch")
+			readTestPacket(t, c, login.EncryptionRequestID, &login.EncryptionRequest{})
+			writeTestPacket(t, c, login.LoginAcknowledgedID, &login.LoginAcknowledged{})
+		}},
+		{name: "Repeated Login Start", packets: func(t *testing.T, c *proto.Conn) {
+			startLogin(t, c, "Notch")
+			readTestPacket(t, c, login.EncryptionRequestID, &login.EncryptionRequest{})
+			writeTestPacket(t, c, login.LoginStartID, &login.LoginStart{Name: "jeb_"})
+		}},
+		{name: "Repeated Encryption Response", packets: func(t *testing.T, c *proto.Conn) {
+			startLogin(t, c, "Notch")
+			var req login.EncryptionRequest
+			readTestPacket(t, c, login.EncryptionRequestID, &req)
+			sharedSecret, res := encryptionResponse(t, req)
+			writeTestPacket(t, c, login.EncryptionResponseID, res)
+			if err := c.EnableEncryption(sharedSecret); err != nil {
+				t.Fatalf("Conn.EnableEncryption() error = %v", err)
+			}
+			readTestPacket(t, c, login.LoginSuccessID, &login.LoginSuccess{})
+			writeTestPacket(t, c, login.EncryptionResponseID, res)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{OnlineMode: true, SessionServer: ts.URL})
+			tt.packets(t, c)
+			if _, err := c.ReadPacket(); err != io.EOF {
+				t.Errorf("Conn.ReadPacket() error = %v, want %v", err, io.EOF)
+			}
+		})
+	}
+}
+
+func TestServer_transfer(t *testing.T) {
+	tests := []struct {
+		name            string
+		acceptTransfers bool
+		wantReason      *text.Component
+	}{
+		{name: "Accepted", acceptTransfers: true},
+		{name: "Disabled", wantReason: &text.Component{Translate: "multiplayer.disconnect.transfers_disabled"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{AcceptTransfers: tt.acceptTransfers})
+			handshake := handshakeLogin
+			handshake.NextState = 3
+			writeTestPacket(t, c, handshaking.HandshakeID, &handshake)
+
+			if tt.wantReason != nil {
+				var res login.Disconnect
+				readTestPacket(t, c, login.DisconnectID, &res)
+				if !reflect.DeepEqual(res.Reason, *tt.wantReason) {
+					t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, *tt.wantReason)
+				}
+				return
+			}
+			writeTestPacket(t, c, login.LoginStartID, &login.LoginStart{Name: "Notch"})
+			var res login.LoginSuccess
+			readTestPacket(t, c, login.LoginSuccessID, &res)
+			if res.Username != "Notch" {
+				t.Errorf("LoginSuccess.Username = %v, want Notch", res.Username)
+			}
+		})
+	}
+}
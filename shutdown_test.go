@@ -0,0 +1,112 @@
+package cobble
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func serveTest(t *testing.T, s *Server, ctx context.Context) (net.Listener, chan error) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.Serve(ctx, l)
+	}()
+	return l, errc
+}
+
+func dialTCP(t *testing.T, l net.Listener) *proto.Conn {
+	t.Helper()
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return proto.NewConn(conn)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	s := &Server{ShutdownMessage: "Restarting"}
+	handled := make(chan struct{})
+	s.Use(func(next Handler) Handler {
+		return HandlerFunc(func(c *Conn, p proto.Packet) error {
+			defer close(handled)
+			return next.ServePacket(c, p)
+		})
+	})
+	l, errc := serveTest(t, s, context.Background())
+
+	c := dialTCP(t, l)
+	writeTestPacket(t, c, handshaking.HandshakeID, &handshakeLogin)
+	<-handled
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	if err := <-errc; !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Serve() error = %v, want %v", err, ErrServerClosed)
+	}
+
+	var res login.Disconnect
+	readTestPacket(t, c, login.DisconnectID, &res)
+	if want := text.Plain("Restarting"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+	if _, err := net.Dial("tcp", l.Addr().String()); err == nil {
+		t.Errorf("Dial() after Shutdown succeeded, want error")
+	}
+}
+
+func TestServer_Shutdown_deadline(t *testing.T) {
+	s := &Server{}
+	s.HandleFunc(proto.StateHandshaking, handshaking.HandshakeID, func(c *Conn, p proto.Packet) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	l, _ := serveTest(t, s, context.Background())
+	c := dialTCP(t, l)
+	writeTestPacket(t, c, handshaking.HandshakeID, &handshakeLogin)
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestServer_Serve_contextCanceled(t *testing.T) {
+	s := &Server{}
+	ctx, cancel := context.WithCancel(context.Background())
+	_, errc := serveTest(t, s, ctx)
+	cancel()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrServerClosed) {
+			t.Errorf("Serve() error = %v, want %v", err, ErrServerClosed)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Serve() did not return after the context was canceled")
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	if err := s.Serve(context.Background(), l); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Serve() after shutdown error = %v, want %v", err, ErrServerClosed)
+	}
+}
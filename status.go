@@ -0,0 +1,58 @@
+package cobble
+
+import (
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/status"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func handleStatusRequest(c *Conn, p proto.Packet) error {
+	var req status.StatusRequest
+	if err := readPacket(p, &req); err != nil {
+		return err
+	}
+	res, err := status.NewStatusResponse(c.Server.status(c))
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(&res)
+}
+
+func handlePingRequest(c *Conn, p proto.Packet) error {
+	var req status.PingRequest
+	if err := readPacket(p, &req); err != nil {
+		return err
+	}
+	res := status.PingResponse{Payload: req.Payload}
+	if err := c.WriteMessage(&res); err != nil {
+		return err
+	}
+	// The ping ends the exchange; vanilla clients close the connection
+	// after it, and others must not keep it open.
+	return c.Close()
+}
+
+func (s *Server) status(c *Conn) status.Response {
+	var res status.Response
+	if s.StatusProvider != nil {
+		res = s.StatusProvider(c)
+	} else {
+		res = status.Response{
+			Players:     &status.Players{Max: 20},
+			Description: text.Plain("A Minecraft Server"),
+		}
+	}
+	if res.Version == (status.Version{}) {
+		// Echoing a supported client's protocol marks the server as
+		// compatible in its list; other clients see the supported range.
+		protocol := proto.LatestVersion.Protocol
+		if v, ok := c.Version(); ok {
+			protocol = v.Protocol
+		}
+		res.Version = status.Version{Name: proto.VersionRange(), Protocol: protocol}
+	}
+	if res.Favicon == "" {
+		res.Favicon = s.Favicon
+	}
+	return res
+}
@@ -0,0 +1,109 @@
+package cobble
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/status"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func requestStatus(t *testing.T, s *Server) status.Response {
+	t.Helper()
+	c := dialTest(t, s)
+	startStatus(t, c)
+	writeTestPacket(t, c, status.StatusRequestID, &status.StatusRequest{})
+
+	var res status.StatusResponse
+	readTestPacket(t, c, status.StatusResponseID, &res)
+	r, err := res.Response()
+	if err != nil {
+		t.Fatalf("StatusResponse.Response() error = %v", err)
+	}
+	return r
+}
+
+func TestServer_defaultStatus(t *testing.T) {
+	got := requestStatus(t, &Server{})
+	want := status.Response{
+		Version:     status.Version{Name: "1.21.3-1.21.4", Protocol: 768},
+		Players:     &status.Players{Max: 20},
+		Description: text.Plain("A Minecraft Server"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("status = %v, want %v", got, want)
+	}
+}
+
+func TestServer_statusVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol int32
+		want     status.Version
+	}{
+		{name: "Supported client", protocol: 769, want: status.Version{Name: "1.21.3-1.21.4", Protocol: 769}},
+		{name: "Unsupported client", protocol: 47, want: status.Version{Name: "1.21.3-1.21.4", Protocol: 769}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dialTest(t, &Server{})
+			writeTestPacket(t, c, handshaking.HandshakeID, &handshaking.Handshake{ProtocolVersion: tt.protocol, ServerAddress: "localhost", ServerPort: 25565, NextState: 1})
+			writeTestPacket(t, c, status.StatusRequestID, &status.StatusRequest{})
+
+			var res status.StatusResponse
+			readTestPacket(t, c, status.StatusResponseID, &res)
+			r, err := res.Response()
+			if err != nil {
+				t.Fatalf("StatusResponse.Response() error = %v", err)
+			}
+			if r.Version != tt.want {
+				t.Errorf("status version = %v, want %v", r.Version, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_StatusProvider(t *testing.T) {
+	s := &Server{StatusProvider: func(c *Conn) status.Response {
+		return status.Response{
+			Players:     &status.Players{Max: 100, Online: 3},
+			Description: text.Plain("Welcome to " + c.Handshake.ServerAddress),
+		}
+	}}
+	got := requestStatus(t, s)
+	want := status.Response{
+		Version:     status.Version{Name: "1.21.3-1.21.4", Protocol: 768},
+		Players:     &status.Players{Max: 100, Online: 3},
+		Description: text.Plain("Welcome to localhost"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("status = %v, want %v", got, want)
+	}
+}
+
+func TestServer_Favicon(t *testing.T) {
+	favicon, err := EncodeFavicon(testImage(64))
+	if err != nil {
+		t.Fatalf("EncodeFavicon() error = %v", err)
+	}
+	got := requestStatus(t, &Server{Favicon: favicon})
+	if got.Favicon != favicon {
+		t.Errorf("status favicon = %v, want %v", got.Favicon, favicon)
+	}
+}
+
+func TestServer_ping(t *testing.T) {
+	c := dialTest(t, &Server{})
+	startStatus(t, c)
+	writeTestPacket(t, c, status.PingRequestID, &status.PingRequest{Payload: 7})
+
+	var res status.PingResponse
+	readTestPacket(t, c, status.PingResponseID, &res)
+	if res.Payload != 7 {
+		t.Errorf("PingResponse.Payload = %v, want 7", res.Payload)
+	}
+	if _, err := c.ReadPacket(); err == nil {
+		t.Errorf("Conn.ReadPacket() error = nil, want the connection closed")
+	}
+}
@@ -0,0 +1,107 @@
+package cobble
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/handshaking"
+	"github.com/nonya123456/cobble/proto/login"
+	"github.com/nonya123456/cobble/proto/status"
+	"github.com/nonya123456/cobble/proto/text"
+)
+
+func TestServer_idleTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		state proto.State
+		start func(t *testing.T, c *proto.Conn)
+	}{
+		{
+			name:  "handshaking",
+			state: proto.StateHandshaking,
+			start: func(t *testing.T, c *proto.Conn) {},
+		},
+		{
+			name:  "status",
+			state: proto.StateStatus,
+			start: startStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{IdleTimeouts: map[proto.State]time.Duration{tt.state: 20 * time.Millisecond}}
+			c := dialTest(t, s)
+			tt.start(t, c)
+
+			if _, err := c.ReadPacket(); err == nil {
+				t.Errorf("Conn.ReadPacket() error = nil, want the connection closed")
+			}
+		})
+	}
+}
+
+func TestServer_idleTimeoutLogin(t *testing.T) {
+	c := dialTest(t, &Server{IdleTimeouts: map[proto.State]time.Duration{proto.StateLogin: 20 * time.Millisecond}})
+	startLogin(t, c, "Notch")
+	readTestPacket(t, c, login.LoginSuccessID, &login.LoginSuccess{})
+
+	var res login.Disconnect
+	readTestPacket(t, c, login.DisconnectID, &res)
+	if want := text.Translatable("disconnect.timeout"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+}
+
+func TestServer_loginTimeout(t *testing.T) {
+	start := time.Now()
+	c := dialTest(t, &Server{LoginTimeout: 50 * time.Millisecond})
+	writeTestPacket(t, c, handshaking.HandshakeID, &handshakeLogin)
+
+	// Packets sent within the idle timeout do not extend the deadline.
+	time.Sleep(30 * time.Millisecond)
+	writeTestPacket(t, c, login.LoginStartID, &login.LoginStart{Name: "Notch"})
+	readTestPacket(t, c, login.LoginSuccessID, &login.LoginSuccess{})
+
+	var res login.Disconnect
+	readTestPacket(t, c, login.DisconnectID, &res)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("disconnected after %v, want at least 50ms", elapsed)
+	}
+	if want := text.Translatable("multiplayer.disconnect.slow_login"); !reflect.DeepEqual(res.Reason, want) {
+		t.Errorf("Disconnect.Reason = %v, want %v", res.Reason, want)
+	}
+}
+
+func TestServer_statusTimeout(t *testing.T) {
+	start := time.Now()
+	c := dialTest(t, &Server{LoginTimeout: 50 * time.Millisecond})
+	startStatus(t, c)
+
+	// Requests within the idle timeout do not keep the connection open.
+	for {
+		time.Sleep(10 * time.Millisecond)
+		if err := c.WritePacket(status.StatusRequestID, &status.StatusRequest{}); err != nil {
+			break
+		}
+		if _, err := c.ReadPacket(); err != nil {
+			break
+		}
+		if time.Since(start) > time.Second {
+			t.Fatalf("status connection still open after %v", time.Since(start))
+		}
+	}
+}
+
+func TestServer_writeTimeout(t *testing.T) {
+	c := dialTest(t, &Server{WriteTimeout: 20 * time.Millisecond})
+	startStatus(t, c)
+	writeTestPacket(t, c, status.StatusRequestID, &status.StatusRequest{})
+
+	// The response is not read until the server has given up writing it.
+	time.Sleep(100 * time.Millisecond)
+	if _, err := c.ReadPacket(); err == nil {
+		t.Errorf("Conn.ReadPacket() error = nil, want the connection closed")
+	}
+}
@@ -0,0 +1,4 @@
+package vanilla
+
+//go:generate go run github.com/nonya123456/cobble/cmd/vanillagen -in generated/1.21.3 -pack 1.21.2,1.21.3 -out data/768.json
+//go:generate go run github.com/nonya123456/cobble/cmd/vanillagen -in generated/1.21.4 -pack 1.21.4 -out data/769.json
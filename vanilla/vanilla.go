@@ -0,0 +1,309 @@
+// Package vanilla bundles the registry and tag data of the supported
+// vanilla releases.
+//
+// Entries that come from the vanilla data pack may have no contents: a
+// client that has the pack fills them in itself. Contents are bundled for
+// the entries servers commonly copy or customize, such as dimension types.
+//
+// The data files can be rebuilt with full contents by cmd/vanillagen: put
+// the vanilla data generator output of each release under
+// generated/<version> and run go generate.
+package vanilla
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/nbt"
+	"github.com/nonya123456/cobble/proto/types"
+)
+
+//go:embed data/*.json
+var files embed.FS
+
+// Data is what the server sends a client of one protocol version during
+// configuration.
+type Data struct {
+	// KnownPacks are the data packs the bundled entries come from.
+	KnownPacks   []configuration.KnownPack
+	FeatureFlags []types.Identifier
+	Registries   []Registry
+
+	// StaticRegistries are built into the client. They are not sent but
+	// give the network IDs of their tagged entries.
+	StaticRegistries []Registry
+
+	// Tags maps a registry to its tags and their entries.
+	Tags map[types.Identifier]map[types.Identifier][]types.Identifier
+}
+
+// Registry is a registry of entries. The index of an entry is its
+// network ID.
+type Registry struct {
+	ID      types.Identifier
+	Entries []Entry
+}
+
+type Entry struct {
+	ID types.Identifier
+	// Data holds the NBT contents of the entry. It may be nil for entries
+	// that are Known.
+	Data any
+	// Known reports whether the entry is unchanged from KnownPacks, so that
+	// clients with one of those packs need not be sent its Data.
+	Known bool
+}
+
+// UnmarshalJSON accepts an entry ID, or an object with the ID and the
+// contents, either as they appear in the vanilla data pack or as SNBT.
+func (e *Entry) UnmarshalJSON(b []byte) error {
+	var id types.Identifier
+	if err := json.Unmarshal(b, &id); err == nil {
+		*e = Entry{ID: id}
+		return nil
+	}
+	var v struct {
+		ID   types.Identifier
+		Data json.RawMessage
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*e = Entry{ID: v.ID}
+	var snbt string
+	if err := json.Unmarshal(v.Data, &snbt); err == nil {
+		if err := nbt.UnmarshalSNBT(snbt, &e.Data); err != nil {
+			return fmt.Errorf("%s: %w", v.ID, err)
+		}
+		return nil
+	}
+	d := json.NewDecoder(bytes.NewReader(v.Data))
+	d.UseNumber()
+	var data any
+	if err := d.Decode(&data); err != nil {
+		return fmt.Errorf("%s: %w", v.ID, err)
+	}
+	converted, err := fromJSON(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", v.ID, err)
+	}
+	e.Data = converted
+	return nil
+}
+
+// fromJSON converts decoded JSON to NBT values the way vanilla does when
+// it sends data pack contents: whole numbers become Ints or Longs, other
+// numbers Doubles and booleans Bytes. A List holds one tag type, so mixed
+// numbers are widened and other mixed elements are each wrapped in a
+// Compound with an empty key, which clients unwrap.
+func fromJSON(v any) (any, error) {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return int8(1), nil
+		}
+		return int8(0), nil
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			if n >= math.MinInt32 && n <= math.MaxInt32 {
+				return int32(n), nil
+			}
+			return n, nil
+		}
+		return v.Float64()
+	case string:
+		return v, nil
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			var err error
+			if m[k], err = fromJSON(e); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case []any:
+		list := make([]any, len(v))
+		for i, e := range v {
+			var err error
+			if list[i], err = fromJSON(e); err != nil {
+				return nil, err
+			}
+		}
+		return homogeneous(list), nil
+	default:
+		return nil, fmt.Errorf("vanilla: cannot convert %v to NBT", v)
+	}
+}
+
+// homogeneous makes the elements of list share one tag type.
+func homogeneous(list []any) []any {
+	kinds := make(map[reflect.Kind]bool)
+	for _, e := range list {
+		kinds[reflect.TypeOf(e).Kind()] = true
+	}
+	if len(kinds) <= 1 {
+		return list
+	}
+	numeric := true
+	for k := range kinds {
+		numeric = numeric && (k == reflect.Int32 || k == reflect.Int64 || k == reflect.Float64)
+	}
+	for i, e := range list {
+		switch {
+		case numeric && kinds[reflect.Float64]:
+			list[i] = reflect.ValueOf(e).Convert(reflect.TypeFor[float64]()).Interface()
+		case numeric:
+			list[i] = reflect.ValueOf(e).Convert(reflect.TypeFor[int64]()).Interface()
+		default:
+			list[i] = map[string]any{"": e}
+		}
+	}
+	return list
+}
+
+// UnsupportedVersionError is returned by Load for a protocol version
+// without bundled data.
+type UnsupportedVersionError struct {
+	Protocol int32
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("vanilla: no data for protocol %d", e.Protocol)
+}
+
+// MissingDataError is returned when a registry entry has to be sent with
+// contents it does not have.
+type MissingDataError struct {
+	Registry types.Identifier
+	Entry    types.Identifier
+}
+
+func (e *MissingDataError) Error() string {
+	return fmt.Sprintf("vanilla: %s entry %s has no data", e.Registry, e.Entry)
+}
+
+// Load returns a new copy of the bundled data for a protocol version,
+// which the caller may modify.
+func Load(protocol int32) (*Data, error) {
+	b, err := files.ReadFile(fmt.Sprintf("data/%d.json", protocol))
+	if err != nil {
+		return nil, &UnsupportedVersionError{protocol}
+	}
+	var d Data
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("vanilla: data for protocol %d: %w", protocol, err)
+	}
+	for _, r := range d.Registries {
+		for i := range r.Entries {
+			r.Entries[i].Known = true
+		}
+	}
+	return &d, nil
+}
+
+// Registry returns the registry with the given ID, or nil.
+func (d *Data) Registry(id types.Identifier) *Registry {
+	for i := range d.Registries {
+		if d.Registries[i].ID == id {
+			return &d.Registries[i]
+		}
+	}
+	return nil
+}
+
+// SetTag replaces the entries of a tag, creating it if needed.
+func (d *Data) SetTag(registry, tag types.Identifier, entries ...types.Identifier) {
+	if d.Tags == nil {
+		d.Tags = make(map[types.Identifier]map[types.Identifier][]types.Identifier)
+	}
+	if d.Tags[registry] == nil {
+		d.Tags[registry] = make(map[types.Identifier][]types.Identifier)
+	}
+	d.Tags[registry][tag] = entries
+}
+
+// RegistryData returns the Registry Data packets for a client. Contents of
+// known entries are left out when the client has one of the KnownPacks.
+func (d *Data) RegistryData(hasKnownPack bool) ([]configuration.RegistryData, error) {
+	packets := make([]configuration.RegistryData, len(d.Registries))
+	for i, r := range d.Registries {
+		entries := make([]configuration.RegistryEntry, len(r.Entries))
+		for j, e := range r.Entries {
+			entries[j].ID = e.ID
+			if e.Known && hasKnownPack {
+				continue
+			}
+			if e.Data == nil {
+				return nil, &MissingDataError{r.ID, e.ID}
+			}
+			entries[j].Data = e.Data
+		}
+		packets[i] = configuration.RegistryData{Registry: r.ID, Entries: entries}
+	}
+	return packets, nil
+}
+
+// UpdateTags returns the Update Tags packet, resolving entries to their
+// network IDs.
+func (d *Data) UpdateTags() (configuration.UpdateTags, error) {
+	var packet configuration.UpdateTags
+	for _, registry := range slices.Sorted(maps.Keys(d.Tags)) {
+		r := d.Registry(registry)
+		if r == nil {
+			if i := slices.IndexFunc(d.StaticRegistries, func(r Registry) bool { return r.ID == registry }); i >= 0 {
+				r = &d.StaticRegistries[i]
+			}
+		}
+		if r == nil {
+			return configuration.UpdateTags{}, fmt.Errorf("vanilla: tags for unknown registry %s", registry)
+		}
+
+		tags := d.Tags[registry]
+		rt := configuration.RegistryTags{Registry: registry}
+		for _, name := range slices.Sorted(maps.Keys(tags)) {
+			tag := configuration.Tag{Name: name, Entries: make([]int32, len(tags[name]))}
+			for i, id := range tags[name] {
+				index := r.Index(id)
+				if index < 0 {
+					return configuration.UpdateTags{}, fmt.Errorf("vanilla: tag %s references unknown %s entry %s", name, registry, id)
+				}
+				tag.Entries[i] = int32(index)
+			}
+			rt.Tags = append(rt.Tags, tag)
+		}
+		packet.Registries = append(packet.Registries, rt)
+	}
+	return packet, nil
+}
+
+// Index returns the network ID of an entry, or -1.
+func (r *Registry) Index(id types.Identifier) int {
+	return slices.IndexFunc(r.Entries, func(e Entry) bool { return e.ID == id })
+}
+
+// Lookup returns the entry with the given ID.
+func (r *Registry) Lookup(id types.Identifier) (Entry, bool) {
+	if i := r.Index(id); i >= 0 {
+		return r.Entries[i], true
+	}
+	return Entry{}, false
+}
+
+// Set replaces the contents of an entry, or appends a new entry. The
+// entry is sent with data to every client.
+func (r *Registry) Set(id types.Identifier, data any) {
+	e := Entry{ID: id, Data: data}
+	if i := r.Index(id); i >= 0 {
+		r.Entries[i] = e
+		return
+	}
+	r.Entries = append(r.Entries, e)
+}
@@ -0,0 +1,334 @@
+package vanilla_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nonya123456/cobble/proto"
+	"github.com/nonya123456/cobble/proto/configuration"
+	"github.com/nonya123456/cobble/proto/types"
+	"github.com/nonya123456/cobble/vanilla"
+)
+
+// requiredRegistries are the synchronized registries vanilla clients
+// refuse to play without.
+var requiredRegistries = []types.Identifier{
+	"minecraft:banner_pattern",
+	"minecraft:chat_type",
+	"minecraft:damage_type",
+	"minecraft:dimension_type",
+	"minecraft:enchantment",
+	"minecraft:instrument",
+	"minecraft:jukebox_song",
+	"minecraft:painting_variant",
+	"minecraft:trim_material",
+	"minecraft:trim_pattern",
+	"minecraft:wolf_variant",
+	"minecraft:worldgen/biome",
+}
+
+func TestLoad(t *testing.T) {
+	for _, v := range proto.Versions {
+		t.Run(v.Name, func(t *testing.T) {
+			d, err := vanilla.Load(v.Protocol)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(d.KnownPacks) == 0 {
+				t.Errorf("Load() has no known packs")
+			}
+			for _, id := range requiredRegistries {
+				r := d.Registry(id)
+				if r == nil || len(r.Entries) == 0 {
+					t.Errorf("Load() registry %s is missing or empty", id)
+				}
+			}
+			if d.Registry("minecraft:worldgen/biome").Index("minecraft:plains") < 0 {
+				t.Errorf("Load() biomes lack minecraft:plains")
+			}
+		})
+	}
+}
+
+func TestLoad_copy(t *testing.T) {
+	d, err := vanilla.Load(768)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	d.Registries = nil
+
+	d, err = vanilla.Load(768)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(d.Registries) == 0 {
+		t.Errorf("Load() returned modified data")
+	}
+}
+
+func TestLoad_unsupported(t *testing.T) {
+	_, err := vanilla.Load(47)
+	var unsupported *vanilla.UnsupportedVersionError
+	if !errors.As(err, &unsupported) || unsupported.Protocol != 47 {
+		t.Errorf("Load() error = %v, want UnsupportedVersionError", err)
+	}
+}
+
+func TestLoad_data(t *testing.T) {
+	d, err := vanilla.Load(768)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	e, ok := d.Registry("minecraft:dimension_type").Lookup("minecraft:overworld")
+	if !ok || !e.Known {
+		t.Fatalf("Registry.Lookup() = %v, %v, want known overworld", e, ok)
+	}
+	data, _ := e.Data.(map[string]any)
+	if data["min_y"] != int32(-64) || data["has_skylight"] != int8(1) || data["coordinate_scale"] != 1.0 {
+		t.Errorf("overworld data = %v", e.Data)
+	}
+}
+
+func testData() *vanilla.Data {
+	return &vanilla.Data{
+		Registries: []vanilla.Registry{
+			{ID: "minecraft:dimension_type", Entries: []vanilla.Entry{
+				{ID: "minecraft:overworld", Data: map[string]any{"height": int32(384)}, Known: true},
+				{ID: "minecraft:the_end", Known: true},
+			}},
+		},
+		StaticRegistries: []vanilla.Registry{
+			{ID: "minecraft:fluid", Entries: []vanilla.Entry{{ID: "minecraft:empty"}, {ID: "minecraft:water"}}},
+		},
+	}
+}
+
+func TestData_RegistryData(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(d *vanilla.Data)
+		hasKnownPack bool
+		want         []configuration.RegistryEntry
+		wantErr      bool
+	}{
+		{
+			name:         "Known pack",
+			hasKnownPack: true,
+			want:         []configuration.RegistryEntry{{ID: "minecraft:overworld"}, {ID: "minecraft:the_end"}},
+		},
+		{
+			name:         "Unknown pack",
+			hasKnownPack: false,
+			wantErr:      true,
+		},
+		{
+			name: "Unknown pack with data",
+			modify: func(d *vanilla.Data) {
+				d.Registry("minecraft:dimension_type").Set("minecraft:the_end", map[string]any{"height": int32(256)})
+			},
+			hasKnownPack: false,
+			want: []configuration.RegistryEntry{
+				{ID: "minecraft:overworld", Data: map[string]any{"height": int32(384)}},
+				{ID: "minecraft:the_end", Data: map[string]any{"height": int32(256)}},
+			},
+		},
+		{
+			name: "Custom entry",
+			modify: func(d *vanilla.Data) {
+				d.Registry("minecraft:dimension_type").Set("example:flat", map[string]any{"height": int32(16)})
+			},
+			hasKnownPack: true,
+			want: []configuration.RegistryEntry{
+				{ID: "minecraft:overworld"},
+				{ID: "minecraft:the_end"},
+				{ID: "example:flat", Data: map[string]any{"height": int32(16)}},
+			},
+		},
+		{
+			name: "Overridden entry",
+			modify: func(d *vanilla.Data) {
+				d.Registry("minecraft:dimension_type").Set("minecraft:overworld", map[string]any{"height": int32(512)})
+			},
+			hasKnownPack: true,
+			want: []configuration.RegistryEntry{
+				{ID: "minecraft:overworld", Data: map[string]any{"height": int32(512)}},
+				{ID: "minecraft:the_end"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testData()
+			if tt.modify != nil {
+				tt.modify(d)
+			}
+			got, err := d.RegistryData(tt.hasKnownPack)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Data.RegistryData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			want := []configuration.RegistryData{{Registry: "minecraft:dimension_type", Entries: tt.want}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Data.RegistryData() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestData_UpdateTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    func(d *vanilla.Data)
+		want    configuration.UpdateTags
+		wantErr bool
+	}{
+		{
+			name: "No tags",
+			tags: func(d *vanilla.Data) {},
+			want: configuration.UpdateTags{},
+		},
+		{
+			name: "Synchronized and static registries",
+			tags: func(d *vanilla.Data) {
+				d.SetTag("minecraft:fluid", "minecraft:water", "minecraft:water")
+				d.SetTag("minecraft:dimension_type", "example:dark", "minecraft:the_end", "minecraft:overworld")
+			},
+			want: configuration.UpdateTags{Registries: []configuration.RegistryTags{
+				{Registry: "minecraft:dimension_type", Tags: []configuration.Tag{{Name: "example:dark", Entries: []int32{1, 0}}}},
+				{Registry: "minecraft:fluid", Tags: []configuration.Tag{{Name: "minecraft:water", Entries: []int32{1}}}},
+			}},
+		},
+		{
+			name: "Unknown entry",
+			tags: func(d *vanilla.Data) {
+				d.SetTag("minecraft:fluid", "minecraft:lava", "minecraft:lava")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown registry",
+			tags: func(d *vanilla.Data) {
+				d.SetTag("minecraft:block", "minecraft:logs", "minecraft:oak_log")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testData()
+			tt.tags(d)
+			got, err := d.UpdateTags()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Data.UpdateTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Data.UpdateTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_tags(t *testing.T) {
+	for _, v := range proto.Versions {
+		t.Run(v.Name, func(t *testing.T) {
+			d, err := vanilla.Load(v.Protocol)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if _, err := d.UpdateTags(); err != nil {
+				t.Errorf("Data.UpdateTags() error = %v", err)
+			}
+			if _, err := d.RegistryData(true); err != nil {
+				t.Errorf("Data.RegistryData() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRegistry_Index(t *testing.T) {
+	r := testData().Registry("minecraft:dimension_type")
+	tests := []struct {
+		id   types.Identifier
+		want int
+	}{
+		{id: "minecraft:overworld", want: 0},
+		{id: "minecraft:the_end", want: 1},
+		{id: "minecraft:the_nether", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.id), func(t *testing.T) {
+			if got := r.Index(tt.id); got != tt.want {
+				t.Errorf("Registry.Index() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntry_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    vanilla.Entry
+		wantErr bool
+	}{
+		{
+			name: "ID",
+			data: `"minecraft:plains"`,
+			want: vanilla.Entry{ID: "minecraft:plains"},
+		},
+		{
+			name: "SNBT",
+			data: `{"id": "minecraft:plains", "data": "{temperature:0.8f,has_precipitation:1b}"}`,
+			want: vanilla.Entry{ID: "minecraft:plains", Data: map[string]any{"temperature": float32(0.8), "has_precipitation": int8(1)}},
+		},
+		{
+			name: "JSON",
+			data: `{"id": "minecraft:plains", "data": {"temperature": 0.8, "has_precipitation": true, "seed": 8589934592, "tint": 7907327}}`,
+			want: vanilla.Entry{ID: "minecraft:plains", Data: map[string]any{
+				"temperature":       0.8,
+				"has_precipitation": int8(1),
+				"seed":              int64(8589934592),
+				"tint":              int32(7907327),
+			}},
+		},
+		{
+			name: "Mixed numbers",
+			data: `{"id": "minecraft:plains", "data": {"a": [1, 0.5], "b": [1, 8589934592]}}`,
+			want: vanilla.Entry{ID: "minecraft:plains", Data: map[string]any{
+				"a": []any{1.0, 0.5},
+				"b": []any{int64(1), int64(8589934592)},
+			}},
+		},
+		{
+			name: "Mixed list",
+			data: `{"id": "minecraft:chat", "data": {"extra": ["a", {"text": "b"}]}}`,
+			want: vanilla.Entry{ID: "minecraft:chat", Data: map[string]any{
+				"extra": []any{map[string]any{"": "a"}, map[string]any{"": map[string]any{"text": "b"}}},
+			}},
+		},
+		{
+			name:    "Null",
+			data:    `{"id": "minecraft:plains", "data": {"effects": null}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got vanilla.Entry
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Entry.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Entry.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}